@@ -12,6 +12,8 @@ import (
 // @title           Swagger Example API
 // @version         1.0
 // @description     Music library example.
+// @description     Every response carries an X-Request-ID header, echoing the one sent with the request if it is valid.
+// @description     Errors are answered with models.ErrorResponse: a stable code, a message, the invalid fields of validation errors and the request id.
 
 // @license.name  Apache 2.0
 // @license.url   http://www.apache.org/licenses/LICENSE-2.0.html
@@ -19,6 +21,16 @@ import (
 // @host      localhost:8080
 // @BasePath  /api
 
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 Access token from /auth/login, sent as "Bearer <token>".
+
+// @securityDefinitions.apikey  ApiKeyAuth
+// @in                          header
+// @name                        X-API-Key
+// @description                 API key issued by an admin; it must hold the scope of the route.
+
 // @externalDocs.description  OpenAPI
 // @externalDocs.url          https://swagger.io/resources/open-api/
 
@@ -1,19 +1,39 @@
 package config
 
 import (
-	"github.com/joho/godotenv"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+	StorageSQLite   = "sqlite"
 )
 
 type AppConfig struct {
+	Storage     string
 	HTTP        HTTP
 	Postgres    Postgres
+	SQLite      SQLite
 	ExternalAPI API
+	Pagination  Pagination
+	Auth        Auth
+	RateLimits  RateLimits
+	Trash       Trash
 }
 
 type HTTP struct {
 	Host string
 	Port string
+	// TrustedProxies may set X-Forwarded-For. Client IPs, and so the rate
+	// limits of clients without an API key, come from the connection when
+	// it is empty.
+	TrustedProxies []string
 }
 
 type Postgres struct {
@@ -24,8 +44,58 @@ type Postgres struct {
 	DBName   string
 }
 
+type SQLite struct {
+	Path string
+}
+
 type API struct {
-	URL string
+	URL              string
+	Timeout          time.Duration
+	MaxRetries       int
+	RetryBackoff     time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+type Pagination struct {
+	// CursorSecret signs pagination cursors. When empty a random secret is
+	// used, so cursors do not survive a restart.
+	CursorSecret string
+}
+
+type Auth struct {
+	// JWTSecret signs access tokens. When empty a random secret is used, so
+	// access tokens do not survive a restart.
+	JWTSecret       string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	// AdminUsers may issue and revoke API keys.
+	AdminUsers []string
+}
+
+// RateLimit is a token bucket per client: Rate requests per second on
+// average, in bursts of up to Burst. A zero Rate disables the limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimits holds the limit of each route group. Clients are told apart by
+// API key, or by IP when they send no valid one.
+type RateLimits struct {
+	Auth      RateLimit
+	Songs     RateLimit
+	Groups    RateLimit
+	Albums    RateLimit
+	Playlists RateLimit
+}
+
+// Trash controls how long deleted songs can be restored.
+type Trash struct {
+	// Retention is how long a song stays in the trash before it is purged
+	// for good. Zero keeps deleted songs forever.
+	Retention     time.Duration
+	PurgeInterval time.Duration
 }
 
 func LoadConfig() (*AppConfig, error) {
@@ -35,9 +105,13 @@ func LoadConfig() (*AppConfig, error) {
 	}
 
 	config := &AppConfig{
+		Storage: getEnv("STORAGE", StoragePostgres),
+
 		HTTP: HTTP{
 			Host: os.Getenv("HTTP_HOST"),
 			Port: os.Getenv("HTTP_PORT"),
+
+			TrustedProxies: getEnvList("TRUSTED_PROXIES"),
 		},
 
 		Postgres: Postgres{
@@ -48,10 +122,85 @@ func LoadConfig() (*AppConfig, error) {
 			DBName:   os.Getenv("DB_NAME"),
 		},
 
+		SQLite: SQLite{
+			Path: getEnv("SQLITE_PATH", "music-library.db"),
+		},
+
 		ExternalAPI: API{
-			URL: os.Getenv("EXTERNAL_API_URL"),
+			URL:              os.Getenv("EXTERNAL_API_URL"),
+			Timeout:          getEnvDuration("EXTERNAL_API_TIMEOUT", 5*time.Second),
+			MaxRetries:       getEnvInt("EXTERNAL_API_MAX_RETRIES", 3),
+			RetryBackoff:     getEnvDuration("EXTERNAL_API_RETRY_BACKOFF", 200*time.Millisecond),
+			BreakerThreshold: getEnvInt("EXTERNAL_API_BREAKER_THRESHOLD", 5),
+			BreakerCooldown:  getEnvDuration("EXTERNAL_API_BREAKER_COOLDOWN", 30*time.Second),
+		},
+
+		Pagination: Pagination{
+			CursorSecret: os.Getenv("CURSOR_SECRET"),
+		},
+
+		Auth: Auth{
+			JWTSecret:       os.Getenv("JWT_SECRET"),
+			AccessTokenTTL:  getEnvDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+			RefreshTokenTTL: getEnvDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+			AdminUsers:      getEnvList("ADMIN_USERS"),
+		},
+
+		RateLimits: RateLimits{
+			Auth:      getEnvRateLimit("RATE_LIMIT_AUTH", RateLimit{Rate: 1, Burst: 10}),
+			Songs:     getEnvRateLimit("RATE_LIMIT_SONGS", RateLimit{Rate: 5, Burst: 20}),
+			Groups:    getEnvRateLimit("RATE_LIMIT_GROUPS", RateLimit{Rate: 10, Burst: 40}),
+			Albums:    getEnvRateLimit("RATE_LIMIT_ALBUMS", RateLimit{Rate: 10, Burst: 40}),
+			Playlists: getEnvRateLimit("RATE_LIMIT_PLAYLISTS", RateLimit{Rate: 10, Burst: 40}),
+		},
+
+		Trash: Trash{
+			Retention:     getEnvDuration("TRASH_RETENTION", 30*24*time.Hour),
+			PurgeInterval: getEnvDuration("TRASH_PURGE_INTERVAL", time.Hour),
 		},
 	}
 
 	return config, nil
 }
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// getEnvRateLimit reads the rate from key and the burst from key_BURST.
+func getEnvRateLimit(key string, fallback RateLimit) RateLimit {
+	if rate, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil {
+		fallback.Rate = rate
+	}
+	fallback.Burst = getEnvInt(key+"_BURST", fallback.Burst)
+	return fallback
+}
+
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
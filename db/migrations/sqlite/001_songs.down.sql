@@ -0,0 +1,3 @@
+DROP TABLE song_verses;
+
+DROP TABLE songs;
@@ -0,0 +1,16 @@
+CREATE TABLE songs (
+   id INTEGER PRIMARY KEY AUTOINCREMENT,
+   group_name VARCHAR(100) NOT NULL,
+   song_name VARCHAR(200) NOT NULL,
+   release_date VARCHAR(50) NOT NULL,
+   link VARCHAR(255) NOT NULL,
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE song_verses (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    song_id INTEGER REFERENCES songs(id) ON DELETE CASCADE,
+    verse_index INTEGER NOT NULL,
+    text TEXT NOT NULL
+);
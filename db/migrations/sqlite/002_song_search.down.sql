@@ -0,0 +1,7 @@
+DROP TRIGGER song_verses_fts_update;
+
+DROP TRIGGER song_verses_fts_delete;
+
+DROP TRIGGER song_verses_fts_insert;
+
+DROP TABLE song_verses_fts;
@@ -0,0 +1,16 @@
+CREATE VIRTUAL TABLE song_verses_fts USING fts5(text, content='song_verses', content_rowid='id');
+
+CREATE TRIGGER song_verses_fts_insert AFTER INSERT ON song_verses BEGIN
+    INSERT INTO song_verses_fts(rowid, text) VALUES (new.id, new.text);
+END;
+
+CREATE TRIGGER song_verses_fts_delete AFTER DELETE ON song_verses BEGIN
+    INSERT INTO song_verses_fts(song_verses_fts, rowid, text) VALUES ('delete', old.id, old.text);
+END;
+
+CREATE TRIGGER song_verses_fts_update AFTER UPDATE ON song_verses BEGIN
+    INSERT INTO song_verses_fts(song_verses_fts, rowid, text) VALUES ('delete', old.id, old.text);
+    INSERT INTO song_verses_fts(rowid, text) VALUES (new.id, new.text);
+END;
+
+INSERT INTO song_verses_fts(song_verses_fts) VALUES ('rebuild');
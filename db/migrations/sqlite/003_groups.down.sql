@@ -0,0 +1,12 @@
+ALTER TABLE songs ADD COLUMN group_name VARCHAR(100) NOT NULL DEFAULT '';
+
+UPDATE songs
+SET group_name = (SELECT g.name FROM groups AS g WHERE g.id = songs.group_id);
+
+-- SQLite cannot drop a column that takes part in a foreign key, so group_id
+-- is only cleared.
+DROP INDEX songs_group_id_idx;
+
+UPDATE songs SET group_id = NULL;
+
+DROP TABLE groups;
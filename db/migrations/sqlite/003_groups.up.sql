@@ -0,0 +1,21 @@
+CREATE TABLE groups (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO groups (name)
+SELECT DISTINCT group_name FROM songs;
+
+-- SQLite cannot add a NOT NULL column to an existing table; the repository
+-- always sets group_id. The default NO ACTION still blocks deleting a group
+-- that has songs and reports a plain foreign key violation, unlike RESTRICT.
+ALTER TABLE songs ADD COLUMN group_id INTEGER REFERENCES groups(id);
+
+UPDATE songs
+SET group_id = (SELECT g.id FROM groups AS g WHERE g.name = songs.group_name);
+
+ALTER TABLE songs DROP COLUMN group_name;
+
+CREATE INDEX songs_group_id_idx ON songs (group_id);
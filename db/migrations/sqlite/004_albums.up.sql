@@ -0,0 +1,20 @@
+CREATE TABLE albums (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    group_id INTEGER NOT NULL REFERENCES groups(id),
+    title VARCHAR(200) NOT NULL,
+    release_date VARCHAR(50) NOT NULL DEFAULT '',
+    cover_link VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (group_id, title)
+);
+
+CREATE TABLE album_tracks (
+    album_id INTEGER NOT NULL REFERENCES albums(id) ON DELETE CASCADE,
+    song_id INTEGER NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    track_number INTEGER NOT NULL CHECK (track_number > 0),
+    PRIMARY KEY (album_id, track_number),
+    UNIQUE (album_id, song_id)
+);
+
+CREATE INDEX album_tracks_song_id_idx ON album_tracks (song_id);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS song_verses_song_id_verse_index_key;
@@ -0,0 +1,8 @@
+-- Close gaps and break ties so every song numbers its verses 1..n.
+UPDATE song_verses
+SET verse_index = n.rn
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY song_id ORDER BY verse_index, id) AS rn
+      FROM song_verses) AS n
+WHERE song_verses.id = n.id AND song_verses.verse_index <> n.rn;
+
+CREATE UNIQUE INDEX song_verses_song_id_verse_index_key ON song_verses (song_id, verse_index);
@@ -0,0 +1,14 @@
+-- before_state and after_state hold the song as JSON, see models.SongSnapshot.
+-- before_state is NULL for the revision that created the song and
+-- after_state for the one that deleted it.
+CREATE TABLE song_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    song_id INTEGER NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    actor VARCHAR(100) NOT NULL DEFAULT '',
+    before_state TEXT,
+    after_state TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (song_id, revision)
+);
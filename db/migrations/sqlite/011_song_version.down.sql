@@ -0,0 +1,3 @@
+DROP TRIGGER songs_touch;
+
+ALTER TABLE songs DROP COLUMN version;
@@ -0,0 +1,15 @@
+-- version counts the changes of a song and its verses. Clients send it back
+-- as If-Match so that an edit only applies to the song they have seen.
+ALTER TABLE songs ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- Every update of a song bumps updated_at and version; changes of verses
+-- touch the song row for the same effect. Moving a song to or from the
+-- trash is not an edit. The version check keeps the trigger from firing
+-- on its own update.
+CREATE TRIGGER songs_touch
+AFTER UPDATE ON songs
+FOR EACH ROW
+WHEN OLD.version = NEW.version AND OLD.deleted_at IS NEW.deleted_at
+BEGIN
+    UPDATE songs SET updated_at = CURRENT_TIMESTAMP, version = OLD.version + 1 WHERE id = NEW.id;
+END;
@@ -0,0 +1,28 @@
+DROP TRIGGER songs_touch;
+
+ALTER TABLE songs ADD COLUMN release_text VARCHAR(50) NOT NULL DEFAULT '';
+
+UPDATE songs
+SET release_text = COALESCE(strftime('%d.%m.%Y', release_date), release_date_unparsed, '');
+
+ALTER TABLE songs DROP COLUMN release_date;
+ALTER TABLE songs DROP COLUMN release_date_unparsed;
+ALTER TABLE songs RENAME COLUMN release_text TO release_date;
+
+UPDATE song_revisions
+SET before_state = json_set(before_state, '$.release_date',
+        COALESCE(strftime('%d.%m.%Y', json_extract(before_state, '$.release_date')), ''))
+WHERE before_state IS NOT NULL;
+
+UPDATE song_revisions
+SET after_state = json_set(after_state, '$.release_date',
+        COALESCE(strftime('%d.%m.%Y', json_extract(after_state, '$.release_date')), ''))
+WHERE after_state IS NOT NULL;
+
+CREATE TRIGGER songs_touch
+AFTER UPDATE ON songs
+FOR EACH ROW
+WHEN OLD.version = NEW.version AND OLD.deleted_at IS NEW.deleted_at
+BEGIN
+    UPDATE songs SET updated_at = CURRENT_TIMESTAMP, version = OLD.version + 1 WHERE id = NEW.id;
+END;
@@ -0,0 +1,70 @@
+-- release_date becomes a DATE. Values written as DD.MM.YYYY, the format the
+-- API used to require, as YYYY-MM-DD or as an ISO 8601 timestamp are
+-- converted. Any other value is kept in release_date_unparsed, with
+-- release_date left NULL, so that those songs can be found and fixed.
+
+-- Converting a song is not an edit of it; the trigger is created again below.
+DROP TRIGGER songs_touch;
+
+ALTER TABLE songs ADD COLUMN release_date_unparsed VARCHAR(50);
+ALTER TABLE songs ADD COLUMN release_day DATE;
+
+UPDATE songs
+SET release_day = CASE
+    WHEN trim(release_date) GLOB '[0-9][0-9].[0-9][0-9].[0-9][0-9][0-9][0-9]'
+        THEN substr(trim(release_date), 7, 4) || '-' || substr(trim(release_date), 4, 2) || '-' || substr(trim(release_date), 1, 2)
+    WHEN trim(release_date) GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]'
+      OR trim(release_date) GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9][T ]*'
+        THEN substr(trim(release_date), 1, 10)
+END;
+
+-- date() is NULL for a month out of range and rolls a day out of range,
+-- such as 2006-02-30, over into the next month.
+UPDATE songs SET release_day = NULL WHERE date(release_day) IS NOT release_day;
+
+UPDATE songs
+SET release_date_unparsed = release_date
+WHERE release_day IS NULL AND trim(release_date) <> '';
+
+ALTER TABLE songs DROP COLUMN release_date;
+ALTER TABLE songs RENAME COLUMN release_day TO release_date;
+
+-- Revisions hold songs as JSON, see models.SongSnapshot, where an unknown
+-- date is the empty string.
+UPDATE song_revisions
+SET before_state = json_set(before_state, '$.release_date', CASE
+    WHEN json_extract(before_state, '$.release_date') GLOB '[0-9][0-9].[0-9][0-9].[0-9][0-9][0-9][0-9]'
+        THEN substr(json_extract(before_state, '$.release_date'), 7, 4) || '-' ||
+             substr(json_extract(before_state, '$.release_date'), 4, 2) || '-' ||
+             substr(json_extract(before_state, '$.release_date'), 1, 2)
+    ELSE substr(json_extract(before_state, '$.release_date'), 1, 10)
+END)
+WHERE before_state IS NOT NULL;
+
+UPDATE song_revisions
+SET before_state = json_set(before_state, '$.release_date', '')
+WHERE before_state IS NOT NULL
+  AND date(json_extract(before_state, '$.release_date')) IS NOT json_extract(before_state, '$.release_date');
+
+UPDATE song_revisions
+SET after_state = json_set(after_state, '$.release_date', CASE
+    WHEN json_extract(after_state, '$.release_date') GLOB '[0-9][0-9].[0-9][0-9].[0-9][0-9][0-9][0-9]'
+        THEN substr(json_extract(after_state, '$.release_date'), 7, 4) || '-' ||
+             substr(json_extract(after_state, '$.release_date'), 4, 2) || '-' ||
+             substr(json_extract(after_state, '$.release_date'), 1, 2)
+    ELSE substr(json_extract(after_state, '$.release_date'), 1, 10)
+END)
+WHERE after_state IS NOT NULL;
+
+UPDATE song_revisions
+SET after_state = json_set(after_state, '$.release_date', '')
+WHERE after_state IS NOT NULL
+  AND date(json_extract(after_state, '$.release_date')) IS NOT json_extract(after_state, '$.release_date');
+
+CREATE TRIGGER songs_touch
+AFTER UPDATE ON songs
+FOR EACH ROW
+WHEN OLD.version = NEW.version AND OLD.deleted_at IS NEW.deleted_at
+BEGIN
+    UPDATE songs SET updated_at = CURRENT_TIMESTAMP, version = OLD.version + 1 WHERE id = NEW.id;
+END;
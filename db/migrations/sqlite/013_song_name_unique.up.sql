@@ -0,0 +1,14 @@
+-- A group has one song of a name outside the trash. Of songs that share a
+-- name the oldest keeps it and the others are renamed to
+-- "<name> (duplicate #<id>)", cut to fit the column, so that nothing is lost
+-- and the duplicates are easy to find and merge by hand. Should a new name be
+-- taken as well, creating the index fails and the migration stops.
+UPDATE songs
+SET song_name = substr(songs.song_name, 1, 200 - length(' (duplicate #' || songs.id || ')')) || ' (duplicate #' || songs.id || ')'
+WHERE songs.deleted_at IS NULL
+  AND EXISTS (SELECT 1
+              FROM songs AS o
+              WHERE o.group_id = songs.group_id AND o.song_name = songs.song_name
+                AND o.deleted_at IS NULL AND o.id < songs.id);
+
+CREATE UNIQUE INDEX songs_group_id_song_name_key ON songs (group_id, song_name) WHERE deleted_at IS NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS playlists_user_id_idx;
+
+ALTER TABLE playlists DROP COLUMN user_id;
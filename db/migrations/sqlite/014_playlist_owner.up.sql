@@ -0,0 +1,5 @@
+-- Playlists belong to the user who made them. Playlists made before they had
+-- owners keep a NULL owner and are hidden until one is set.
+ALTER TABLE playlists ADD COLUMN user_id INTEGER REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE INDEX playlists_user_id_idx ON playlists (user_id);
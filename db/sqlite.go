@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"embed"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jmoiron/sqlx"
+	moderncsqlite "modernc.org/sqlite"
+
+	"github.com/LionJr/music-library/config"
+)
+
+//go:embed migrations/sqlite/*.sql
+var sqliteMigrations embed.FS
+
+// SQLite's lower() only folds ASCII, so repositories use casefold() for
+// case-insensitive matching of non-Latin titles.
+func init() {
+	moderncsqlite.MustRegisterDeterministicScalarFunction("casefold", 1,
+		func(_ *moderncsqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+			switch v := args[0].(type) {
+			case string:
+				return strings.ToLower(v), nil
+			case []byte:
+				return strings.ToLower(string(v)), nil
+			default:
+				return v, nil
+			}
+		},
+	)
+}
+
+func NewSQLiteDB(ctx context.Context, cfg *config.SQLite) (*sqlx.DB, error) {
+	// foreign_keys is a per-connection setting, so it has to go through the
+	// DSN for ON DELETE CASCADE to work on every pooled connection.
+	dsn := cfg.Path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+
+	db, err := sqlx.Connect("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("connect to sqlite: %w", err)
+	}
+
+	// SQLite allows a single writer; one connection avoids SQLITE_BUSY and
+	// keeps ":memory:" databases from being split across connections.
+	db.SetMaxOpenConns(1)
+
+	if err = db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("ping sqlite: %w", err)
+	}
+
+	if err = runSQLiteMigrations(db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func runSQLiteMigrations(db *sqlx.DB) error {
+	src, err := iofs.New(sqliteMigrations, "migrations/sqlite")
+	if err != nil {
+		return fmt.Errorf("open sqlite migrations: %w", err)
+	}
+	defer func() { _ = src.Close() }()
+
+	driver, err := sqlite.WithInstance(db.DB, &sqlite.Config{})
+	if err != nil {
+		return fmt.Errorf("create sqlite migrate driver: %w", err)
+	}
+
+	// m.Close would also close db, so only the source is released above.
+	m, err := migrate.NewWithInstance("iofs", src, "sqlite", driver)
+	if err != nil {
+		return fmt.Errorf("create migrate instance: %w", err)
+	}
+
+	if err = m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("run migrations: %w", err)
+	}
+
+	return nil
+}
@@ -11,7 +11,14 @@ import (
 	"github.com/LionJr/music-library/config"
 	"github.com/LionJr/music-library/db"
 	"github.com/LionJr/music-library/internal/app/http/server"
+	"github.com/LionJr/music-library/internal/provider/songinfo"
+	"github.com/LionJr/music-library/internal/repository/memory"
 	"github.com/LionJr/music-library/internal/repository/postgres"
+	"github.com/LionJr/music-library/internal/repository/sqlite"
+	"github.com/LionJr/music-library/internal/service/album"
+	"github.com/LionJr/music-library/internal/service/auth"
+	"github.com/LionJr/music-library/internal/service/group"
+	"github.com/LionJr/music-library/internal/service/playlist"
 	"github.com/LionJr/music-library/internal/service/song"
 )
 
@@ -22,6 +29,7 @@ type Application struct {
 	logger *zap.Logger
 	db     *sqlx.DB
 	http   *server.Server
+	songs  *song.Service
 }
 
 func New(ctx context.Context) (*Application, error) {
@@ -35,24 +43,74 @@ func New(ctx context.Context) (*Application, error) {
 		return nil, fmt.Errorf("init logger: %w", err)
 	}
 
-	postgresDB, err := db.NewDB(ctx, &cfg.Postgres)
-	if err != nil {
-		return nil, fmt.Errorf("connect postgres: %w", err)
+	var (
+		database     *sqlx.DB
+		songRepo     song.Repo
+		groupRepo    group.Repo
+		albumRepo    album.Repo
+		playlistRepo playlist.Repo
+		authRepo     auth.Repo
+		keyRepo      auth.KeyRepo
+	)
+
+	switch cfg.Storage {
+	case config.StoragePostgres:
+		database, err = db.NewDB(ctx, &cfg.Postgres)
+		if err != nil {
+			return nil, fmt.Errorf("connect postgres: %w", err)
+		}
+		songRepo = postgres.NewSongRepository(database)
+		groupRepo = postgres.NewGroupRepository(database)
+		albumRepo = postgres.NewAlbumRepository(database)
+		playlistRepo = postgres.NewPlaylistRepository(database)
+		authRepo = postgres.NewUserRepository(database)
+		keyRepo = postgres.NewAPIKeyRepository(database)
+	case config.StorageSQLite:
+		database, err = db.NewSQLiteDB(ctx, &cfg.SQLite)
+		if err != nil {
+			return nil, fmt.Errorf("open sqlite: %w", err)
+		}
+		songRepo = sqlite.NewSongRepository(database)
+		groupRepo = sqlite.NewGroupRepository(database)
+		albumRepo = sqlite.NewAlbumRepository(database)
+		playlistRepo = sqlite.NewPlaylistRepository(database)
+		authRepo = sqlite.NewUserRepository(database)
+		keyRepo = sqlite.NewAPIKeyRepository(database)
+	case config.StorageMemory:
+		storage := memory.NewStorage()
+		songRepo = memory.NewSongRepository(storage)
+		groupRepo = memory.NewGroupRepository(storage)
+		albumRepo = memory.NewAlbumRepository(storage)
+		playlistRepo = memory.NewPlaylistRepository(storage)
+		authRepo = memory.NewUserRepository(storage)
+		keyRepo = memory.NewAPIKeyRepository(storage)
+	default:
+		return nil, fmt.Errorf("unknown storage %q", cfg.Storage)
 	}
 
-	songRepo := postgres.NewSongRepository(postgresDB)
-	songService := song.NewService(cfg, logger, songRepo)
+	logger.Info("storage initialized", zap.String("storage", cfg.Storage))
+
+	songInfo := songinfo.NewHTTPProvider(&cfg.ExternalAPI, logger)
+	songService := song.NewService(cfg, logger, songRepo, songInfo)
+	groupService := group.NewService(cfg, logger, groupRepo)
+	albumService := album.NewService(cfg, logger, albumRepo)
+	playlistService := playlist.NewService(cfg, logger, playlistRepo)
+	authService := auth.NewService(cfg, logger, authRepo, keyRepo)
 
 	return &Application{
 		cfg:    cfg,
 		logger: logger,
-		db:     postgresDB,
-		http:   server.New(cfg, logger, songService),
+		db:     database,
+		http:   server.New(cfg, logger, authService, songService, groupService, albumService, playlistService),
+		songs:  songService,
 	}, nil
 }
 
 func (a *Application) Run(ctx context.Context) error {
 	a.logger.Info("application started")
+
+	go a.songs.RunTrashPurge(ctx)
+
 	return a.http.Run(ctx)
 }
 
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+)
+
+// requestIDPattern is what a request id sent by a client may look like; other
+// ids are replaced.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// requestID gives every request an id, the one the client sent in
+// X-Request-ID or a random one, and sends it back in the same header. Error
+// responses and the request log take the id from there.
+func requestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(models.RequestIDHeader)
+		if !requestIDPattern.MatchString(id) {
+			b := make([]byte, 16)
+			_, _ = rand.Read(b)
+			id = hex.EncodeToString(b)
+		}
+
+		ctx.Header(models.RequestIDHeader, id)
+		ctx.Next()
+	}
+}
+
+// recovery answers a request whose handler panicked with an internal error.
+func recovery(logger *zap.Logger) gin.HandlerFunc {
+	return gin.CustomRecovery(func(ctx *gin.Context, err any) {
+		logger.Error("http handler panicked",
+			zap.Any("error", err),
+			zap.String("request_id", ctx.Writer.Header().Get(models.RequestIDHeader)),
+		)
+		abortWithError(ctx, "internal server error", http.StatusInternalServerError)
+	})
+}
+
+// abortWithError ends a request with an error response.
+func abortWithError(ctx *gin.Context, msg string, status int) {
+	respond.Error(ctx, msg, status)
+	ctx.Abort()
+}
+
+// requestLogger logs every request after it is handled, together with the
+// user or API key that made it.
+func requestLogger(logger *zap.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		ctx.Next()
+
+		fields := []zap.Field{
+			zap.String("method", ctx.Request.Method),
+			zap.String("path", ctx.Request.URL.Path),
+			zap.Int("status", ctx.Writer.Status()),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("client_ip", ctx.ClientIP()),
+			zap.String("request_id", ctx.Writer.Header().Get(models.RequestIDHeader)),
+		}
+		if keyId, ok := ctx.Get("api_key_id"); ok {
+			fields = append(fields, zap.Any("api_key_id", keyId))
+		}
+		if userId, ok := ctx.Get("user_id"); ok {
+			fields = append(fields, zap.Any("user_id", userId))
+		}
+
+		logger.Info("http request", fields...)
+	}
+}
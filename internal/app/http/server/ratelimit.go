@@ -0,0 +1,127 @@
+package server
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/LionJr/music-library/config"
+	"github.com/LionJr/music-library/internal/service/auth"
+)
+
+// sweepInterval is how often buckets that have refilled completely are
+// dropped; a dropped bucket is indistinguishable from a new one.
+const sweepInterval = time.Minute
+
+// limiter keeps one token bucket per client. A bucket holds up to burst
+// tokens and refills at rate tokens per second; every request takes one.
+type limiter struct {
+	mu        sync.Mutex
+	rate      float64
+	burst     float64
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+func newLimiter(rate float64, burst int) *limiter {
+	return &limiter{
+		rate:    rate,
+		burst:   math.Max(float64(burst), 1),
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// take takes a token from the bucket of client. It returns whether the
+// request may proceed, the tokens left, how long until the bucket is full
+// again and, for rejected requests, how long until the next token.
+func (l *limiter) take(client string) (bool, int, time.Duration, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &bucket{tokens: l.burst, updated: now}
+		l.buckets[client] = b
+	}
+
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.updated).Seconds()*l.rate)
+	b.updated = now
+
+	allowed := b.tokens >= 1
+	var retryAfter time.Duration
+	if allowed {
+		b.tokens--
+	} else {
+		retryAfter = l.duration(1 - b.tokens)
+	}
+
+	return allowed, int(b.tokens), l.duration(l.burst - b.tokens), retryAfter
+}
+
+// duration returns how long the bucket takes to gain tokens.
+func (l *limiter) duration(tokens float64) time.Duration {
+	return time.Duration(tokens / l.rate * float64(time.Second))
+}
+
+// sweep drops full buckets. The caller must hold mu.
+func (l *limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for client, b := range l.buckets {
+		if b.tokens+now.Sub(b.updated).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, client)
+		}
+	}
+}
+
+// rateLimit limits each client of a route group to limit. It must run after
+// auth.Service.IdentifyKey: clients are told apart by the id of a valid API
+// key, or by IP otherwise, so made-up keys share the limit of their IP. Users
+// logged in with an access token share the limit of their IP as well.
+func rateLimit(limit config.RateLimit) gin.HandlerFunc {
+	if limit.Rate <= 0 {
+		return func(*gin.Context) {}
+	}
+
+	return newLimiter(limit.Rate, limit.Burst).handle
+}
+
+func (l *limiter) handle(ctx *gin.Context) {
+	client := "ip:" + ctx.ClientIP()
+	if keyId, ok := auth.KeyID(ctx); ok {
+		client = "key:" + strconv.Itoa(keyId)
+	}
+
+	allowed, remaining, reset, retryAfter := l.take(client)
+
+	ctx.Header("X-RateLimit-Limit", strconv.Itoa(int(l.burst)))
+	ctx.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	ctx.Header("X-RateLimit-Reset", strconv.Itoa(seconds(reset)))
+
+	if !allowed {
+		ctx.Header("Retry-After", strconv.Itoa(max(seconds(retryAfter), 1)))
+		abortWithError(ctx, "too many requests", http.StatusTooManyRequests)
+	}
+}
+
+// seconds rounds d up to whole seconds, as the rate limit headers use them.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
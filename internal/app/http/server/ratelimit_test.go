@@ -0,0 +1,138 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/LionJr/music-library/config"
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/repository/memory"
+	"github.com/LionJr/music-library/internal/service/auth"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func newTestLimiter(rate float64, burst int) (*limiter, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newLimiter(rate, burst)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestLimiterTake(t *testing.T) {
+	l, now := newTestLimiter(2, 3)
+
+	for i := 3; i > 0; i-- {
+		allowed, remaining, reset, _ := l.take("a")
+		if !allowed || remaining != i-1 {
+			t.Fatalf("got allowed %v, remaining %d, want true, %d", allowed, remaining, i-1)
+		}
+		if want := time.Duration(4-i) * 500 * time.Millisecond; reset != want {
+			t.Errorf("got reset %v, want %v", reset, want)
+		}
+	}
+
+	allowed, _, _, retryAfter := l.take("a")
+	if allowed || retryAfter != 500*time.Millisecond {
+		t.Fatalf("empty bucket: got allowed %v, retry after %v, want false, 500ms", allowed, retryAfter)
+	}
+
+	if allowed, _, _, _ := l.take("b"); !allowed {
+		t.Error("other client: got rejected")
+	}
+
+	*now = now.Add(500 * time.Millisecond)
+	if allowed, remaining, _, _ := l.take("a"); !allowed || remaining != 0 {
+		t.Errorf("after refill: got allowed %v, remaining %d, want true, 0", allowed, remaining)
+	}
+
+	*now = now.Add(time.Hour)
+	if _, remaining, _, _ := l.take("a"); remaining != 2 {
+		t.Errorf("after a long pause: got remaining %d, want the burst less one", remaining)
+	}
+}
+
+func TestLimiterSweep(t *testing.T) {
+	l, now := newTestLimiter(1, 2)
+
+	l.take("a")
+	l.take("b")
+	l.take("b")
+
+	*now = now.Add(sweepInterval)
+	l.take("c")
+
+	// a and b have refilled completely; c was just taken from.
+	if len(l.buckets) != 1 || l.buckets["c"] == nil {
+		t.Errorf("got buckets %v, want only c", l.buckets)
+	}
+}
+
+func TestRateLimitKeys(t *testing.T) {
+	storage := memory.NewStorage()
+	authService := auth.NewService(&config.AppConfig{}, zap.NewNop(), memory.NewUserRepository(storage), memory.NewAPIKeyRepository(storage))
+
+	addKey := func(secret string) int {
+		sum := sha256.Sum256([]byte(secret))
+		id, err := authService.Keys.AddAPIKey(t.Context(), &models.APIKey{
+			Name: secret, Prefix: secret, KeyHash: hex.EncodeToString(sum[:]), Scopes: models.Scopes{models.ScopeSongsRead},
+		})
+		if err != nil {
+			t.Fatalf("AddAPIKey: %v", err)
+		}
+		return id
+	}
+	addKey("valid")
+	if err := authService.Keys.RevokeAPIKey(t.Context(), addKey("revoked"), time.Now()); err != nil {
+		t.Fatalf("RevokeAPIKey: %v", err)
+	}
+
+	l, _ := newTestLimiter(1, 1)
+	send := func(remoteAddr, key string) int {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/api/songs", nil)
+		ctx.Request.RemoteAddr = remoteAddr
+		if key != "" {
+			ctx.Request.Header.Set(auth.APIKeyHeader, key)
+		}
+
+		authService.IdentifyKey(ctx)
+		l.handle(ctx)
+		return w.Code
+	}
+
+	if got := send("10.0.0.1:1000", ""); got != http.StatusOK {
+		t.Fatalf("first request: got status %d", got)
+	}
+
+	// Made-up and revoked keys count against the IP.
+	for _, key := range []string{"", "made-up-1", "made-up-2", "revoked"} {
+		if got := send("10.0.0.1:1000", key); got != http.StatusTooManyRequests {
+			t.Errorf("key %q: got status %d, want %d", key, got, http.StatusTooManyRequests)
+		}
+	}
+	if n := len(l.buckets); n != 1 {
+		t.Errorf("got %d buckets, want 1", n)
+	}
+
+	// A valid key has a bucket of its own, whatever the IP.
+	if got := send("10.0.0.1:1000", "valid"); got != http.StatusOK {
+		t.Errorf("valid key: got status %d, want %d", got, http.StatusOK)
+	}
+	if got := send("10.0.0.2:1000", "valid"); got != http.StatusTooManyRequests {
+		t.Errorf("valid key from another IP: got status %d, want %d", got, http.StatusTooManyRequests)
+	}
+	if got := send("10.0.0.2:1000", ""); got != http.StatusOK {
+		t.Errorf("another IP: got status %d, want %d", got, http.StatusOK)
+	}
+}
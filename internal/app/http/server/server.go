@@ -12,25 +12,38 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/LionJr/music-library/config"
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/album"
+	"github.com/LionJr/music-library/internal/service/auth"
+	"github.com/LionJr/music-library/internal/service/group"
+	"github.com/LionJr/music-library/internal/service/playlist"
 	"github.com/LionJr/music-library/internal/service/song"
 
 	_ "github.com/LionJr/music-library/docs"
 )
 
 type Server struct {
-	cfg         *config.AppConfig
-	logger      *zap.Logger
-	songService *song.Service
-	srv         *http.Server
+	cfg             *config.AppConfig
+	logger          *zap.Logger
+	authService     *auth.Service
+	songService     *song.Service
+	groupService    *group.Service
+	albumService    *album.Service
+	playlistService *playlist.Service
+	srv             *http.Server
 }
 
-func New(cfg *config.AppConfig, logger *zap.Logger, songService *song.Service) *Server {
+func New(cfg *config.AppConfig, logger *zap.Logger, authService *auth.Service, songService *song.Service, groupService *group.Service, albumService *album.Service, playlistService *playlist.Service) *Server {
 	return &Server{
-		cfg:         cfg,
-		logger:      logger,
-		songService: songService,
+		cfg:             cfg,
+		logger:          logger,
+		authService:     authService,
+		songService:     songService,
+		groupService:    groupService,
+		albumService:    albumService,
+		playlistService: playlistService,
 		srv: &http.Server{
-			Handler: initHandlers(songService),
+			Handler: initHandlers(cfg, logger, authService, songService, groupService, albumService, playlistService),
 			Addr:    ":" + cfg.HTTP.Port,
 		},
 	}
@@ -73,8 +86,19 @@ func (s *Server) Shutdown(ctx context.Context) error {
 	return nil
 }
 
-func initHandlers(songService *song.Service) *gin.Engine {
-	router := gin.Default()
+func initHandlers(cfg *config.AppConfig, logger *zap.Logger, authService *auth.Service, songService *song.Service, groupService *group.Service, albumService *album.Service, playlistService *playlist.Service) *gin.Engine {
+	router := gin.New()
+	// Handlers pass the gin context on to the repositories, which read the
+	// actor of revisions from the request context.
+	router.ContextWithFallback = true
+	router.Use(requestID(), recovery(logger), requestLogger(logger))
+	if err := router.SetTrustedProxies(cfg.HTTP.TrustedProxies); err != nil {
+		logger.Warn("invalid trusted proxies", zap.Error(err))
+	}
+
+	router.NoRoute(func(ctx *gin.Context) {
+		abortWithError(ctx, "route not found", http.StatusNotFound)
+	})
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
@@ -82,14 +106,80 @@ func initHandlers(songService *song.Service) *gin.Engine {
 		c.String(http.StatusOK, "OK")
 	})
 
-	api := router.Group("/api")
-	songsRouter := api.Group("/songs")
-
-	songsRouter.GET("/", songService.GetSongs)
-	songsRouter.GET("/:id/verses", songService.GetVerses)
-	songsRouter.DELETE("/:id", songService.Delete)
-	songsRouter.PATCH("/:id", songService.Edit)
-	songsRouter.POST("/", songService.Add)
+	// API keys are looked up before the rate limits, which count requests
+	// with a valid key by key and all others by IP.
+	api := router.Group("/api", authService.IdentifyKey)
+
+	// Reads are public; everything that modifies the library needs a bearer
+	// access token or an API key with the scope of the route. API keys are
+	// checked for the read scope as well.
+	allow, require := authService.Allow, authService.Require
+
+	authRouter := api.Group("/auth", rateLimit(cfg.RateLimits.Auth))
+
+	authRouter.POST("/register", authService.Register)
+	authRouter.POST("/login", authService.Login)
+	authRouter.POST("/refresh", authService.Refresh)
+	authRouter.POST("/logout", authService.Logout)
+
+	adminRouter := api.Group("/admin", authService.RequireAdmin)
+
+	adminRouter.GET("/api-keys", authService.GetAPIKeys)
+	adminRouter.DELETE("/api-keys/:id", authService.RevokeAPIKey)
+	adminRouter.POST("/api-keys", authService.IssueAPIKey)
+
+	songsRouter := api.Group("/songs", rateLimit(cfg.RateLimits.Songs))
+
+	songsRouter.GET("/", allow(models.ScopeSongsRead), songService.GetSongs)
+	songsRouter.GET("/export", allow(models.ScopeSongsRead), songService.Export)
+	songsRouter.GET("/search", allow(models.ScopeSongsRead), songService.Search)
+	songsRouter.GET("/:id", allow(models.ScopeSongsRead), songService.GetSong)
+	songsRouter.GET("/:id/verses", allow(models.ScopeSongsRead), songService.GetVerses)
+	songsRouter.GET("/:id/revisions", allow(models.ScopeSongsRead), songService.GetRevisions)
+	songsRouter.GET("/:id/revisions/diff", allow(models.ScopeSongsRead), songService.DiffRevisions)
+	songsRouter.DELETE("/:id", require(models.ScopeSongsDelete), songService.Delete)
+	songsRouter.DELETE("/:id/verses/:index", require(models.ScopeSongsWrite), songService.DeleteVerse)
+	songsRouter.PATCH("/:id", require(models.ScopeSongsWrite), songService.Edit)
+	songsRouter.PUT("/:id/text", require(models.ScopeSongsWrite), songService.ReplaceText)
+	songsRouter.POST("/", require(models.ScopeSongsWrite), songService.Add)
+	songsRouter.POST("/import", require(models.ScopeSongsWrite), songService.Import)
+	songsRouter.POST("/:id/restore", require(models.ScopeSongsDelete), songService.Restore)
+	songsRouter.POST("/:id/revisions/:rev/restore", require(models.ScopeSongsWrite), songService.RestoreRevision)
+	songsRouter.POST("/:id/verses", require(models.ScopeSongsWrite), songService.AddVerse)
+	songsRouter.POST("/:id/verses/reorder", require(models.ScopeSongsWrite), songService.ReorderVerses)
+
+	trashRouter := api.Group("/trash", rateLimit(cfg.RateLimits.Songs))
+
+	trashRouter.GET("/", require(models.ScopeSongsDelete), songService.GetTrash)
+
+	groupsRouter := api.Group("/groups", rateLimit(cfg.RateLimits.Groups))
+
+	groupsRouter.GET("/", allow(models.ScopeGroupsRead), groupService.GetGroups)
+	groupsRouter.GET("/:id", allow(models.ScopeGroupsRead), groupService.GetGroup)
+	groupsRouter.GET("/:id/songs", allow(models.ScopeGroupsRead), groupService.GetSongs)
+	groupsRouter.DELETE("/:id", require(models.ScopeGroupsDelete), groupService.Delete)
+	groupsRouter.PATCH("/:id", require(models.ScopeGroupsWrite), groupService.Edit)
+	groupsRouter.POST("/", require(models.ScopeGroupsWrite), groupService.Add)
+
+	albumsRouter := api.Group("/albums", rateLimit(cfg.RateLimits.Albums))
+
+	albumsRouter.GET("/", allow(models.ScopeAlbumsRead), albumService.GetAlbums)
+	albumsRouter.GET("/:id", allow(models.ScopeAlbumsRead), albumService.GetAlbum)
+	albumsRouter.DELETE("/:id", require(models.ScopeAlbumsDelete), albumService.Delete)
+	albumsRouter.PATCH("/:id", require(models.ScopeAlbumsWrite), albumService.Edit)
+	albumsRouter.POST("/", require(models.ScopeAlbumsWrite), albumService.Add)
+
+	playlistsRouter := api.Group("/playlists", rateLimit(cfg.RateLimits.Playlists))
+
+	playlistsRouter.GET("/", require(models.ScopePlaylistsRead), playlistService.GetPlaylists)
+	playlistsRouter.GET("/:id", require(models.ScopePlaylistsRead), playlistService.GetPlaylist)
+	playlistsRouter.GET("/:id/items", require(models.ScopePlaylistsRead), playlistService.GetItems)
+	playlistsRouter.DELETE("/:id", require(models.ScopePlaylistsDelete), playlistService.Delete)
+	playlistsRouter.DELETE("/:id/items/:position", require(models.ScopePlaylistsWrite), playlistService.RemoveItem)
+	playlistsRouter.PATCH("/:id", require(models.ScopePlaylistsWrite), playlistService.Edit)
+	playlistsRouter.POST("/", require(models.ScopePlaylistsWrite), playlistService.Add)
+	playlistsRouter.POST("/:id/items", require(models.ScopePlaylistsWrite), playlistService.AddItem)
+	playlistsRouter.POST("/:id/items/move", require(models.ScopePlaylistsWrite), playlistService.MoveItem)
 
 	return router
 }
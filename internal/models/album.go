@@ -0,0 +1,57 @@
+package models
+
+type Album struct {
+	ID          int     `json:"id" db:"id"`
+	GroupID     int     `json:"group_id" db:"group_id"`
+	GroupName   string  `json:"group_name" db:"group_name"`
+	Title       string  `json:"title" db:"title"`
+	ReleaseDate string  `json:"release_date" db:"release_date"`
+	CoverLink   string  `json:"cover_link" db:"cover_link"`
+	CreatedAt   string  `json:"created_at" db:"created_at"`
+	UpdatedAt   string  `json:"updated_at" db:"updated_at"`
+	Tracks      []Track `json:"tracks,omitempty"`
+}
+
+// Track is a song's position on an album. Numbers start at 1 and may have
+// gaps once songs are deleted.
+type Track struct {
+	Number   int    `json:"number" db:"track_number"`
+	SongID   int    `json:"song_id" db:"song_id"`
+	SongName string `json:"song_name" db:"song_name"`
+}
+
+// AlbumFilter narrows down GetAlbums. Title is a case-insensitive substring
+// match and a zero GroupID matches every group.
+type AlbumFilter struct {
+	GroupID int
+	Title   string
+}
+
+type NewAlbumRequest struct {
+	GroupID     int    `json:"group_id"`
+	Title       string `json:"title"`
+	ReleaseDate string `json:"release_date"`
+	CoverLink   string `json:"cover_link"`
+	// Tracks lists song ids in track order.
+	Tracks []int `json:"tracks"`
+}
+
+type NewAlbumResponse struct {
+	Message string `json:"message"`
+	AlbumID int    `json:"album_id"`
+}
+
+type EditAlbumRequest struct {
+	Title       *string `json:"title"`
+	ReleaseDate *string `json:"release_date"`
+	CoverLink   *string `json:"cover_link"`
+	// Tracks replaces the whole track listing, renumbering it from 1. Tracks
+	// of songs in the trash are dropped with the rest.
+	Tracks *[]int `json:"tracks"`
+}
+
+type GetAlbumsResponse struct {
+	Albums          []Album `json:"albums"`
+	TotalAlbumCount int     `json:"total_album_count"`
+	Page            int     `json:"page"`
+}
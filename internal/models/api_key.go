@@ -0,0 +1,87 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Scopes grant an API key access to one action on one resource. Users logged
+// in with an access token are not limited by scopes.
+const (
+	ScopeSongsRead       = "songs:read"
+	ScopeSongsWrite      = "songs:write"
+	ScopeSongsDelete     = "songs:delete"
+	ScopeGroupsRead      = "groups:read"
+	ScopeGroupsWrite     = "groups:write"
+	ScopeGroupsDelete    = "groups:delete"
+	ScopeAlbumsRead      = "albums:read"
+	ScopeAlbumsWrite     = "albums:write"
+	ScopeAlbumsDelete    = "albums:delete"
+	ScopePlaylistsRead   = "playlists:read"
+	ScopePlaylistsWrite  = "playlists:write"
+	ScopePlaylistsDelete = "playlists:delete"
+)
+
+var KnownScopes = []string{
+	ScopeSongsRead, ScopeSongsWrite, ScopeSongsDelete,
+	ScopeGroupsRead, ScopeGroupsWrite, ScopeGroupsDelete,
+	ScopeAlbumsRead, ScopeAlbumsWrite, ScopeAlbumsDelete,
+	ScopePlaylistsRead, ScopePlaylistsWrite, ScopePlaylistsDelete,
+}
+
+// Scopes is stored as a single space-separated column.
+type Scopes []string
+
+func (s Scopes) Has(scope string) bool {
+	return slices.Contains(s, scope)
+}
+
+func (s Scopes) Value() (driver.Value, error) {
+	return strings.Join(s, " "), nil
+}
+
+func (s *Scopes) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		*s = strings.Fields(v)
+	case []byte:
+		*s = strings.Fields(string(v))
+	case nil:
+		*s = nil
+	default:
+		return fmt.Errorf("scan scopes from %T", src)
+	}
+	return nil
+}
+
+// APIKey is a stored API key. Only the SHA-256 hash of the key is kept,
+// together with its first characters so that keys can be told apart.
+type APIKey struct {
+	ID        int     `json:"id" db:"id"`
+	Name      string  `json:"name" db:"name"`
+	Prefix    string  `json:"prefix" db:"key_prefix"`
+	KeyHash   string  `json:"-" db:"key_hash"`
+	Scopes    Scopes  `json:"scopes" db:"scopes" swaggertype:"array,string"`
+	CreatedBy *int    `json:"created_by" db:"created_by"`
+	CreatedAt string  `json:"created_at" db:"created_at"`
+	RevokedAt *string `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+type NewAPIKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type NewAPIKeyResponse struct {
+	Message string `json:"message"`
+	ID      int    `json:"id"`
+	// Key is only returned here; it cannot be recovered later.
+	Key    string   `json:"key"`
+	Scopes []string `json:"scopes"`
+}
+
+type GetAPIKeysResponse struct {
+	APIKeys []APIKey `json:"api_keys"`
+}
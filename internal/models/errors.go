@@ -0,0 +1,70 @@
+package models
+
+import "errors"
+
+// ErrNotFound and ErrDuplicate are the kinds of the errors below: errors.Is
+// reports every "does not exist" error as ErrNotFound and every "already
+// exists" error as ErrDuplicate.
+var (
+	ErrNotFound  = errors.New("not found")
+	ErrDuplicate = errors.New("already exists")
+)
+
+var (
+	ErrGroupExists   = kindError(ErrDuplicate, CodeGroupExists, "group already exists")
+	ErrGroupNotEmpty = newError(CodeGroupNotEmpty, "group still has songs or albums")
+	ErrGroupNotFound = kindError(ErrNotFound, CodeGroupNotFound, "group does not exist")
+	ErrAlbumExists   = kindError(ErrDuplicate, CodeAlbumExists, "album already exists")
+	ErrAlbumNotFound = kindError(ErrNotFound, CodeAlbumNotFound, "album does not exist")
+	ErrSongNotFound  = kindError(ErrNotFound, CodeSongNotFound, "song does not exist")
+	// ErrSongExists means the group already has a song of that name outside
+	// the trash.
+	ErrSongExists = kindError(ErrDuplicate, CodeSongExists, "song already exists")
+	// ErrVersionMismatch means a song was changed since the version an edit
+	// was based on.
+	ErrVersionMismatch = newError(CodeVersionMismatch, "song was changed in the meantime")
+
+	ErrVerseNotFound     = kindError(ErrNotFound, CodeVerseNotFound, "no verse found with provided index")
+	ErrRevisionNotFound  = kindError(ErrNotFound, CodeRevisionNotFound, "song revision does not exist")
+	ErrInvalidVerseOrder = newError(CodeInvalidVerseOrder, "verse order must list every verse index once")
+
+	ErrPlaylistNotFound     = kindError(ErrNotFound, CodePlaylistNotFound, "playlist does not exist")
+	ErrPlaylistItemNotFound = kindError(ErrNotFound, CodePlaylistItemNotFound, "playlist item does not exist")
+
+	ErrUserExists = kindError(ErrDuplicate, CodeUserExists, "user already exists")
+	// ErrRefreshTokenReused means an already rotated refresh token was
+	// presented again; every token of its user has been revoked.
+	ErrRefreshTokenReused  = newError(CodeRefreshTokenReused, "refresh token reused")
+	ErrInvalidRefreshToken = newError(CodeInvalidRefreshToken, "invalid refresh token")
+
+	ErrAPIKeyNotFound = kindError(ErrNotFound, CodeAPIKeyNotFound, "api key does not exist")
+)
+
+type domainError struct {
+	msg  string
+	code string
+	kind error
+}
+
+func newError(code, msg string) error {
+	return &domainError{msg: msg, code: code}
+}
+
+func kindError(kind error, code, msg string) error {
+	return &domainError{msg: msg, code: code, kind: kind}
+}
+
+func (e *domainError) Error() string { return e.msg }
+
+func (e *domainError) Unwrap() error { return e.kind }
+
+// DomainErrorCode returns the error code of err if it is one of the errors
+// above or wraps one.
+func DomainErrorCode(err error) (string, bool) {
+	var de *domainError
+	if !errors.As(err, &de) {
+		return "", false
+	}
+
+	return de.code, true
+}
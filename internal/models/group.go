@@ -0,0 +1,33 @@
+package models
+
+type Group struct {
+	ID        int    `json:"id" db:"id"`
+	Name      string `json:"name" db:"name"`
+	CreatedAt string `json:"created_at" db:"created_at"`
+	UpdatedAt string `json:"updated_at" db:"updated_at"`
+}
+
+type NewGroupRequest struct {
+	Name string `json:"name"`
+}
+
+type NewGroupResponse struct {
+	Message string `json:"message"`
+	GroupID int    `json:"group_id"`
+}
+
+type EditGroupRequest struct {
+	Name *string `json:"name"`
+}
+
+type GetGroupsResponse struct {
+	Groups          []Group `json:"groups"`
+	TotalGroupCount int     `json:"total_group_count"`
+	Page            int     `json:"page"`
+}
+
+type GetGroupSongsResponse struct {
+	Songs          []Song `json:"songs"`
+	TotalSongCount int    `json:"total_song_count"`
+	Page           int    `json:"page"`
+}
@@ -0,0 +1,24 @@
+package models
+
+// Page selects a window of rows. With a nil Cursor it is a plain
+// LIMIT/OFFSET page; otherwise Offset is ignored and rows are taken right
+// after (or, for a Backward cursor, right before) the cursor position.
+type Page struct {
+	Offset int
+	Limit  int
+	Cursor *Cursor
+}
+
+// Cursor is the decoded form of the next_cursor/prev_cursor tokens. Key is
+// the boundary row's sort value and ID its primary key, which together form
+// the keyset predicate. Scope, SortBy, Desc and Filter, a hash of the
+// listing's filter, tie a cursor to the listing it was issued for.
+type Cursor struct {
+	Scope    string `json:"sc"`
+	Key      string `json:"k,omitempty"`
+	ID       int    `json:"i"`
+	Backward bool   `json:"b,omitempty"`
+	SortBy   string `json:"s,omitempty"`
+	Desc     bool   `json:"d,omitempty"`
+	Filter   string `json:"f,omitempty"`
+}
@@ -0,0 +1,63 @@
+package models
+
+// Playlist is a playlist of a user. Only its owner sees it.
+type Playlist struct {
+	ID        int    `json:"id" db:"id"`
+	UserID    int    `json:"user_id" db:"user_id"`
+	Name      string `json:"name" db:"name"`
+	CreatedAt string `json:"created_at" db:"created_at"`
+	UpdatedAt string `json:"updated_at" db:"updated_at"`
+}
+
+// PlaylistItem is a song at a position of a playlist. Positions start at 1
+// and stay contiguous as items are added, moved and removed.
+type PlaylistItem struct {
+	ItemID   int `json:"item_id" db:"item_id"`
+	Position int `json:"position" db:"position"`
+	// SortKey is the stored order of the item, positions are derived from
+	// it. Cursors carry it because it does not shift when other items are
+	// removed.
+	SortKey int `json:"-" db:"sort_key"`
+	Song
+}
+
+type NewPlaylistRequest struct {
+	Name string `json:"name"`
+}
+
+type NewPlaylistResponse struct {
+	Message    string `json:"message"`
+	PlaylistID int    `json:"playlist_id"`
+}
+
+type EditPlaylistRequest struct {
+	Name *string `json:"name"`
+}
+
+type AddPlaylistItemRequest struct {
+	SongID int `json:"song_id"`
+}
+
+type AddPlaylistItemResponse struct {
+	Message  string `json:"message"`
+	Position int    `json:"position"`
+}
+
+type MovePlaylistItemRequest struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+type GetPlaylistsResponse struct {
+	Playlists          []Playlist `json:"playlists"`
+	TotalPlaylistCount int        `json:"total_playlist_count"`
+	Page               int        `json:"page"`
+}
+
+type GetPlaylistItemsResponse struct {
+	Items          []PlaylistItem `json:"items"`
+	TotalItemCount int            `json:"total_item_count"`
+	Page           int            `json:"page,omitempty"`
+	NextCursor     string         `json:"next_cursor,omitempty"`
+	PrevCursor     string         `json:"prev_cursor,omitempty"`
+}
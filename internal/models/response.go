@@ -1,21 +1,121 @@
 package models
 
-type Response struct {
-	Status  string      `json:"status"`
-	Data    interface{} `json:"data,omitempty"`
-	Message string      `json:"message"`
-}
+import (
+	"net/http"
+	"strings"
+)
 
 type SuccessResponse struct {
 	Message string      `json:"message"`
-	Data    interface{} `json:"data"`
+	Data    interface{} `json:"data,omitempty"`
 }
 
+// RequestIDHeader carries the id of a request. The server takes it from the
+// request or makes one up, sends it back and logs it with the request.
+const RequestIDHeader = "X-Request-ID"
+
+// Error codes name the kind of an error response; unlike messages they never
+// change.
+const (
+	CodeInvalidRequest     = "invalid_request"
+	CodeValidationFailed   = "validation_failed"
+	CodeUnauthorized       = "unauthorized"
+	CodeForbidden          = "forbidden"
+	CodeNotFound           = "not_found"
+	CodeConflict           = "conflict"
+	CodePreconditionFailed = "precondition_failed"
+	CodePayloadTooLarge    = "payload_too_large"
+	CodeRateLimited        = "rate_limited"
+	CodeInternal           = "internal_error"
+	CodeUnavailable        = "service_unavailable"
+)
+
+// Codes of the domain errors, which are more specific than the codes of their
+// statuses.
+const (
+	CodeGroupNotFound        = "group_not_found"
+	CodeGroupExists          = "group_exists"
+	CodeGroupNotEmpty        = "group_not_empty"
+	CodeAlbumNotFound        = "album_not_found"
+	CodeAlbumExists          = "album_exists"
+	CodeSongNotFound         = "song_not_found"
+	CodeSongExists           = "song_exists"
+	CodeVersionMismatch      = "version_mismatch"
+	CodeVerseNotFound        = "verse_not_found"
+	CodeRevisionNotFound     = "revision_not_found"
+	CodeInvalidVerseOrder    = "invalid_verse_order"
+	CodePlaylistNotFound     = "playlist_not_found"
+	CodePlaylistItemNotFound = "playlist_item_not_found"
+	CodeUserExists           = "user_exists"
+	CodeRefreshTokenReused   = "refresh_token_reused"
+	CodeInvalidRefreshToken  = "invalid_refresh_token"
+	CodeAPIKeyNotFound       = "api_key_not_found"
+)
+
+// ErrorResponse is the body of every error response.
 type ErrorResponse struct {
-	Message string `json:"msg"`
+	// Code is the kind of the error.
+	Code string `json:"code" enums:"invalid_request,validation_failed,unauthorized,forbidden,not_found,conflict,precondition_failed,payload_too_large,rate_limited,internal_error,service_unavailable,group_not_found,group_exists,group_not_empty,album_not_found,album_exists,song_not_found,song_exists,version_mismatch,verse_not_found,revision_not_found,invalid_verse_order,playlist_not_found,playlist_item_not_found,user_exists,refresh_token_reused,invalid_refresh_token,api_key_not_found" example:"validation_failed"`
+	// Message describes the error for humans.
+	Message string `json:"message" example:"invalid release date"`
+	// Details lists the invalid fields of a validation_failed error.
+	Details []FieldError `json:"details,omitempty"`
+	// RequestID is the id of the request, as sent in X-Request-ID.
+	RequestID string `json:"request_id" example:"4f9c1d2e7a6b8c3d"`
+}
+
+// FieldError is the problem with one field of a request: a body field, a
+// query parameter or a path parameter.
+type FieldError struct {
+	Field   string `json:"field" example:"release_date"`
+	Message string `json:"message" example:"invalid release date"`
+}
+
+// NewErrorResponse makes the body of an error response with status. Details
+// make it a validation_failed error, with their messages as the message.
+func NewErrorResponse(status int, message, requestID string, details ...FieldError) ErrorResponse {
+	resp := ErrorResponse{
+		Code:      ErrorCode(status),
+		Message:   message,
+		Details:   details,
+		RequestID: requestID,
+	}
+
+	if len(details) > 0 {
+		messages := make([]string, len(details))
+		for i, d := range details {
+			messages[i] = d.Message
+		}
+
+		resp.Code = CodeValidationFailed
+		resp.Message = strings.Join(messages, "; ")
+	}
+
+	return resp
 }
 
-type ErrorResponseWithCode struct {
-	ErrorCode int    `json:"error_code"`
-	Message   string `json:"msg"`
+// ErrorCode is the code of an error response with status.
+func ErrorCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeInvalidRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusPreconditionFailed:
+		return CodePreconditionFailed
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusServiceUnavailable:
+		return CodeUnavailable
+	default:
+		return CodeInternal
+	}
 }
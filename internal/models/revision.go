@@ -0,0 +1,128 @@
+package models
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Revision actions name the change a revision records.
+const (
+	RevisionCreate        = "create"
+	RevisionEdit          = "edit"
+	RevisionAddVerse      = "add_verse"
+	RevisionDeleteVerse   = "delete_verse"
+	RevisionReorderVerses = "reorder_verses"
+	RevisionReplaceText   = "replace_text"
+	RevisionDelete        = "delete"
+	RevisionRestore       = "restore"
+	RevisionRollback      = "rollback"
+)
+
+// SongSnapshot is the state of a song as a revision records it.
+type SongSnapshot struct {
+	GroupName   string   `json:"group_name"`
+	SongName    string   `json:"song_name"`
+	ReleaseDate Date     `json:"release_date"`
+	Link        string   `json:"link"`
+	Verses      []string `json:"verses"`
+}
+
+// SongSnapshot is stored as a JSON column.
+func (s SongSnapshot) Value() (driver.Value, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (s *SongSnapshot) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	case []byte:
+		return json.Unmarshal(v, s)
+	default:
+		return fmt.Errorf("scan song snapshot from %T", src)
+	}
+}
+
+// SongRevision records one change of a song. Before is nil for the revision
+// that created the song and After is nil for the one that deleted it.
+type SongRevision struct {
+	SongID    int           `json:"song_id" db:"song_id"`
+	Revision  int           `json:"revision" db:"revision"`
+	Action    string        `json:"action" db:"action"`
+	Actor     string        `json:"actor" db:"actor"`
+	CreatedAt string        `json:"created_at" db:"created_at"`
+	Before    *SongSnapshot `json:"before" db:"before_state"`
+	After     *SongSnapshot `json:"after" db:"after_state"`
+}
+
+// State returns the song as it was right after the revision. A deletion
+// has no after state, so for it the song as it was deleted is returned.
+func (r *SongRevision) State() *SongSnapshot {
+	if r.After != nil {
+		return r.After
+	}
+	return r.Before
+}
+
+type GetSongRevisionsResponse struct {
+	Revisions          []SongRevision `json:"revisions"`
+	TotalRevisionCount int            `json:"total_revision_count"`
+	Page               int            `json:"page"`
+}
+
+// FieldChange is a song field that differs between two revisions.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// Verse change kinds.
+const (
+	VerseAdded   = "added"
+	VerseRemoved = "removed"
+	VerseChanged = "changed"
+)
+
+// VerseChange is a verse that was added, removed or changed between two
+// revisions. FromIndex and ToIndex are the positions of the verse in the
+// older and the newer revision, 0 where it does not exist.
+type VerseChange struct {
+	Change    string `json:"change"`
+	FromIndex int    `json:"from_index,omitempty"`
+	ToIndex   int    `json:"to_index,omitempty"`
+	From      string `json:"from,omitempty"`
+	To        string `json:"to,omitempty"`
+}
+
+type RevisionDiffResponse struct {
+	From   int           `json:"from"`
+	To     int           `json:"to"`
+	Fields []FieldChange `json:"fields"`
+	Verses []VerseChange `json:"verses"`
+}
+
+type RestoreRevisionResponse struct {
+	Message  string `json:"message"`
+	Revision int    `json:"revision"`
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx that carries the user or API key making
+// the request; revisions record it.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor stored by WithActor, or "" when there is none.
+func ActorFrom(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
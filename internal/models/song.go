@@ -1,19 +1,111 @@
 package models
 
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
 const (
 	DefaultPaginationPage = 1
 	DefaultPaginationSize = 3
+	// MaxPaginationSize bounds the limit a client may ask for; larger limits
+	// are cut down to it.
+	MaxPaginationSize = 100
+)
+
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
 )
 
+// SongSortColumns lists the columns GET /songs can be sorted by.
+var SongSortColumns = map[string]bool{
+	"id":           true,
+	"group_name":   true,
+	"song_name":    true,
+	"release_date": true,
+	"link":         true,
+	"created_at":   true,
+	"updated_at":   true,
+	"track_number": true,
+}
+
 type Song struct {
 	ID          int    `json:"id" db:"id"`
+	GroupID     int    `json:"group_id" db:"group_id"`
 	GroupName   string `json:"group_name" db:"group_name"`
 	SongName    string `json:"song_name" db:"song_name"`
-	ReleaseDate string `json:"releaseDate" db:"release_date"`
+	ReleaseDate Date   `json:"releaseDate" db:"release_date"`
 	Link        string `json:"link" db:"link"`
 	Text        string `json:"text"`
 	CreatedAt   string `json:"created_at" db:"created_at"`
 	UpdatedAt   string `json:"updated_at" db:"updated_at"`
+	// Version grows with every change of the song or its verses; it is the
+	// ETag of the song.
+	Version int `json:"version" db:"version"`
+	// TrackNumber is only set when songs are listed by album.
+	TrackNumber *int `json:"track_number,omitempty" db:"track_number"`
+	// DeletedAt is only set for songs in the trash.
+	DeletedAt *string `json:"deleted_at,omitempty" db:"deleted_at"`
+}
+
+// SortValue returns the value songs are ordered by when sorting by column;
+// it is what keyset cursors carry as their key. Sorting by id needs no key.
+func (s *Song) SortValue(column string) string {
+	switch column {
+	case "group_name":
+		return s.GroupName
+	case "song_name":
+		return s.SongName
+	case "release_date":
+		return string(s.ReleaseDate)
+	case "link":
+		return s.Link
+	case "created_at":
+		return s.CreatedAt
+	case "updated_at":
+		return s.UpdatedAt
+	case "track_number":
+		if s.TrackNumber == nil {
+			return ""
+		}
+		return strconv.Itoa(*s.TrackNumber)
+	default:
+		return ""
+	}
+}
+
+// DateLayout is the ISO 8601 form dates are stored and returned in.
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date in DateLayout, or "" when it is not known. It is
+// stored in a DATE column, as NULL when it is not known.
+type Date string
+
+func (d Date) Value() (driver.Value, error) {
+	if d == "" {
+		return nil, nil
+	}
+	return string(d), nil
+}
+
+func (d *Date) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*d = ""
+	case time.Time:
+		*d = Date(v.Format(DateLayout))
+	case string:
+		*d = Date(v[:min(len(v), len(DateLayout))])
+	case []byte:
+		*d = Date(v[:min(len(v), len(DateLayout))])
+	default:
+		return fmt.Errorf("scan date from %T", src)
+	}
+	return nil
 }
 
 type Verse struct {
@@ -23,11 +115,59 @@ type Verse struct {
 	Text   string `json:"text" db:"text"`
 }
 
+type AddVerseRequest struct {
+	Text string `json:"text"`
+	// Position is the index the new verse gets; the verse is appended when
+	// it is omitted.
+	Position int `json:"position,omitempty"`
+}
+
+type AddVerseResponse struct {
+	Message string `json:"message"`
+	Index   int    `json:"index"`
+}
+
+type ReplaceTextRequest struct {
+	Text string `json:"text"`
+}
+
+type ReplaceTextResponse struct {
+	Message    string `json:"message"`
+	VerseCount int    `json:"verse_count"`
+}
+
+type ReorderVersesRequest struct {
+	// Order lists the current index of every verse in its new order.
+	Order []int `json:"order"`
+}
+
 type VerseToUpdate struct {
 	Index int    `json:"index" db:"verse_index"`
 	Text  string `json:"text" db:"text"`
 }
 
+// SongExport is a song with all of its verses, as written by the export;
+// Text holds the verses joined back together.
+type SongExport struct {
+	Song
+	Verses []Verse `json:"verses"`
+}
+
+// SplitVerses splits a song text into verses, which are separated by an
+// empty line.
+func SplitVerses(text string) []string {
+	return strings.Split(text, "\n\n")
+}
+
+// JoinVerses is the inverse of SplitVerses.
+func JoinVerses(verses []Verse) string {
+	texts := make([]string, 0, len(verses))
+	for _, verse := range verses {
+		texts = append(texts, verse.Text)
+	}
+	return strings.Join(texts, "\n\n")
+}
+
 type NewSongRequest struct {
 	GroupName string `json:"group"`
 	SongName  string `json:"song"`
@@ -38,6 +178,37 @@ type NewSongResponse struct {
 	SongID  int    `json:"song_id"`
 }
 
+// ImportSongRow is one song of a bulk import. CSV uploads name the columns
+// after the JSON fields in a header row.
+type ImportSongRow struct {
+	GroupName   string `json:"group"`
+	SongName    string `json:"song"`
+	ReleaseDate string `json:"release_date"`
+	Link        string `json:"link"`
+	Text        string `json:"text"`
+}
+
+const (
+	ImportStatusCreated = "created"
+	ImportStatusSkipped = "skipped"
+	ImportStatusFailed  = "failed"
+)
+
+type ImportRowResult struct {
+	// Row counts the songs of the upload from 1, not counting a CSV header.
+	Row    int    `json:"row"`
+	Status string `json:"status"`
+	SongID int    `json:"song_id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type ImportSongsResponse struct {
+	Created int               `json:"created"`
+	Skipped int               `json:"skipped"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
 type EditSongRequest struct {
 	GroupName   *string        `json:"group"`
 	SongName    *string        `json:"song"`
@@ -49,11 +220,59 @@ type EditSongRequest struct {
 type GetSongVerseResponse struct {
 	Verses          []Verse `json:"verses"`
 	TotalVerseCount int     `json:"total_verse_count"`
-	Page            int     `json:"page"`
+	Page            int     `json:"page,omitempty"`
+	NextCursor      string  `json:"next_cursor,omitempty"`
+	PrevCursor      string  `json:"prev_cursor,omitempty"`
 }
 
-type GetSongsResponse struct {
+// SongFilter narrows down GetSongs. Group and Song are case-insensitive
+// substring matches, the time bounds are inclusive and nil means unbounded.
+// A non-zero AlbumID keeps the songs of that album and fills in their track
+// numbers; sorting by track_number requires it.
+type SongFilter struct {
+	AlbumID        int
+	Group          string
+	Song           string
+	ReleasedAfter  *time.Time
+	ReleasedBefore *time.Time
+	CreatedAfter   *time.Time
+	CreatedBefore  *time.Time
+	SortBy         string
+	SortDesc       bool
+}
+
+// GetTrashResponse lists deleted songs, most recently deleted first.
+type GetTrashResponse struct {
 	Songs          []Song `json:"songs"`
 	TotalSongCount int    `json:"total_song_count"`
 	Page           int    `json:"page"`
 }
+
+type GetSongsResponse struct {
+	Songs          []Song `json:"songs"`
+	TotalSongCount int    `json:"total_song_count"`
+	Page           int    `json:"page,omitempty"`
+	NextCursor     string `json:"next_cursor,omitempty"`
+	PrevCursor     string `json:"prev_cursor,omitempty"`
+}
+
+type SongDetail struct {
+	ReleaseDate string `json:"releaseDate"`
+	Text        string `json:"text"`
+	Link        string `json:"link"`
+}
+
+type VerseSearchResult struct {
+	SongID     int     `json:"song_id" db:"song_id"`
+	GroupName  string  `json:"group_name" db:"group_name"`
+	SongName   string  `json:"song_name" db:"song_name"`
+	VerseIndex int     `json:"verse_index" db:"verse_index"`
+	Snippet    string  `json:"snippet" db:"snippet"`
+	Rank       float64 `json:"rank" db:"rank"`
+}
+
+type SearchSongsResponse struct {
+	Results    []VerseSearchResult `json:"results"`
+	TotalCount int                 `json:"total_count"`
+	Page       int                 `json:"page"`
+}
@@ -0,0 +1,90 @@
+package songinfo
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("song info circuit breaker is open")
+
+// CircuitOpenError is returned while the circuit is open. It matches
+// ErrCircuitOpen and tells how long until the next probe is let through.
+type CircuitOpenError struct {
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string { return ErrCircuitOpen.Error() }
+
+func (e *CircuitOpenError) Unwrap() error { return ErrCircuitOpen }
+
+// breaker is a consecutive-failure circuit breaker. After threshold failures
+// in a row it rejects calls until cooldown has elapsed, then lets a single
+// probe through; the probe's outcome closes or re-opens the circuit.
+type breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	now       func() time.Time
+}
+
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+func (b *breaker) allow() error {
+	if b.threshold <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return nil
+	}
+
+	// While a probe is out the next one waits for at least its outcome.
+	if elapsed := b.now().Sub(b.openedAt); b.probing || elapsed < b.cooldown {
+		return &CircuitOpenError{RetryAfter: max(b.cooldown-elapsed, 0)}
+	}
+
+	b.probing = true
+	return nil
+}
+
+// release ends a call that had no outcome, such as one whose context was
+// cancelled. A probe ends without closing or re-opening the circuit, so the
+// next call is let through as the probe.
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+func (b *breaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *breaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.failures >= b.threshold {
+		b.openedAt = b.now()
+	}
+}
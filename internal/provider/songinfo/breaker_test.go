@@ -0,0 +1,137 @@
+package songinfo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestBreaker(threshold int, cooldown time.Duration) (*breaker, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newBreaker(threshold, cooldown)
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestBreakerOpensAfterThreshold(t *testing.T) {
+	b, _ := newTestBreaker(3, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		b.failure()
+		if err := b.allow(); err != nil {
+			t.Fatalf("after %d failures: got error %v, want none", i+1, err)
+		}
+	}
+
+	b.failure()
+	var open *CircuitOpenError
+	if err := b.allow(); !errors.As(err, &open) {
+		t.Fatalf("got error %v, want CircuitOpenError", err)
+	}
+	if open.RetryAfter != time.Minute {
+		t.Errorf("got RetryAfter %v, want %v", open.RetryAfter, time.Minute)
+	}
+}
+
+func TestBreakerSuccessResetsFailures(t *testing.T) {
+	b, _ := newTestBreaker(2, time.Minute)
+
+	b.failure()
+	b.success()
+	b.failure()
+	if err := b.allow(); err != nil {
+		t.Errorf("got error %v, want none", err)
+	}
+}
+
+func TestBreakerProbesAfterCooldown(t *testing.T) {
+	b, now := newTestBreaker(1, time.Minute)
+	b.failure()
+
+	*now = now.Add(40 * time.Second)
+	var open *CircuitOpenError
+	if err := b.allow(); !errors.As(err, &open) {
+		t.Fatalf("during cooldown: got error %v, want CircuitOpenError", err)
+	}
+	if open.RetryAfter != 20*time.Second {
+		t.Errorf("got RetryAfter %v, want %v", open.RetryAfter, 20*time.Second)
+	}
+
+	*now = now.Add(20 * time.Second)
+	if err := b.allow(); err != nil {
+		t.Fatalf("after cooldown: got error %v, want the probe through", err)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("while probing: got error %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestBreakerProbeOutcome(t *testing.T) {
+	b, now := newTestBreaker(1, time.Minute)
+	b.failure()
+	*now = now.Add(time.Minute)
+
+	if err := b.allow(); err != nil {
+		t.Fatalf("probe: got error %v, want none", err)
+	}
+	b.failure()
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("after failed probe: got error %v, want ErrCircuitOpen", err)
+	}
+
+	*now = now.Add(time.Minute)
+	if err := b.allow(); err != nil {
+		t.Fatalf("second probe: got error %v, want none", err)
+	}
+	b.success()
+	if err := b.allow(); err != nil {
+		t.Errorf("after successful probe: got error %v, want none", err)
+	}
+}
+
+func TestBreakerDisabled(t *testing.T) {
+	b, _ := newTestBreaker(0, time.Minute)
+
+	for i := 0; i < 5; i++ {
+		b.failure()
+	}
+	if err := b.allow(); err != nil {
+		t.Errorf("got error %v, want none", err)
+	}
+}
+
+func TestBreakerCancelledProbe(t *testing.T) {
+	var fail atomic.Bool
+	fail.Store(true)
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if fail.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"text": "Paranoia is in bloom"}`))
+	})
+	p.cfg.MaxRetries = 0
+	for i := 0; i < p.cfg.BreakerThreshold; i++ {
+		p.FetchSongDetail(context.Background(), "Muse", "Uprising")
+	}
+
+	now := time.Now().Add(p.cfg.BreakerCooldown)
+	p.breaker.now = func() time.Time { return now }
+	fail.Store(false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.FetchSongDetail(ctx, "Muse", "Uprising"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled probe: got error %v, want context.Canceled", err)
+	}
+
+	if _, err := p.FetchSongDetail(context.Background(), "Muse", "Uprising"); err != nil {
+		t.Fatalf("next probe: got error %v, want none", err)
+	}
+	if err := p.breaker.allow(); err != nil {
+		t.Errorf("after successful probe: got error %v, want none", err)
+	}
+}
@@ -0,0 +1,121 @@
+package songinfo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/LionJr/music-library/config"
+	"github.com/LionJr/music-library/internal/models"
+)
+
+var ErrNotFound = errors.New("song info not found")
+
+type HTTPProvider struct {
+	cfg     *config.API
+	logger  *zap.Logger
+	client  *http.Client
+	breaker *breaker
+}
+
+func NewHTTPProvider(cfg *config.API, logger *zap.Logger) *HTTPProvider {
+	return &HTTPProvider{
+		cfg:     cfg,
+		logger:  logger,
+		client:  &http.Client{Timeout: cfg.Timeout},
+		breaker: newBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
+	}
+}
+
+// FetchSongDetail queries the external API for a song's release date, text
+// and link. Transport errors and 5xx/429 responses are retried with
+// exponential backoff; every call goes through the circuit breaker.
+func (p *HTTPProvider) FetchSongDetail(ctx context.Context, group, song string) (*models.SongDetail, error) {
+	if err := p.breaker.allow(); err != nil {
+		return nil, err
+	}
+
+	detail, err := p.fetchWithRetry(ctx, group, song)
+	switch {
+	case err == nil, errors.Is(err, ErrNotFound):
+		p.breaker.success()
+	case ctx.Err() == nil:
+		p.breaker.failure()
+	default:
+		p.breaker.release()
+	}
+
+	return detail, err
+}
+
+func (p *HTTPProvider) fetchWithRetry(ctx context.Context, group, song string) (*models.SongDetail, error) {
+	backoff := p.cfg.RetryBackoff
+
+	for attempt := 0; ; attempt++ {
+		detail, retryable, err := p.fetch(ctx, group, song)
+		if err == nil || !retryable || attempt >= p.cfg.MaxRetries {
+			return detail, err
+		}
+
+		p.logger.Info("songinfo: retrying request",
+			zap.Int("attempt", attempt+1),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+func (p *HTTPProvider) fetch(ctx context.Context, group, song string) (*models.SongDetail, bool, error) {
+	endpoint, err := url.Parse(p.cfg.URL)
+	if err != nil {
+		return nil, false, fmt.Errorf("parse external api url: %w", err)
+	}
+
+	query := endpoint.Query()
+	query.Set("group", group)
+	query.Set("song", song)
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("build song info request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, ctx.Err() == nil, fmt.Errorf("request song info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, false, ErrNotFound
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode >= http.StatusInternalServerError:
+		return nil, true, fmt.Errorf("song info: unexpected status %d", resp.StatusCode)
+	default:
+		return nil, false, fmt.Errorf("song info: unexpected status %d", resp.StatusCode)
+	}
+
+	var detail models.SongDetail
+	if err = json.NewDecoder(resp.Body).Decode(&detail); err != nil {
+		return nil, false, fmt.Errorf("decode song info: %w", err)
+	}
+
+	return &detail, false, nil
+}
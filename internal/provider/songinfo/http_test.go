@@ -0,0 +1,146 @@
+package songinfo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/LionJr/music-library/config"
+)
+
+func newTestProvider(t *testing.T, handler http.HandlerFunc) *HTTPProvider {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return NewHTTPProvider(&config.API{
+		URL:              srv.URL + "/info",
+		Timeout:          time.Second,
+		MaxRetries:       2,
+		RetryBackoff:     time.Millisecond,
+		BreakerThreshold: 3,
+		BreakerCooldown:  time.Minute,
+	}, zap.NewNop())
+}
+
+func TestFetchSongDetailEncodesQuery(t *testing.T) {
+	var group, song, rawQuery string
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		group, song, rawQuery = r.URL.Query().Get("group"), r.URL.Query().Get("song"), r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"releaseDate":"16.07.2006","text":"Ooh baby","link":"https://example.com"}`))
+	})
+
+	detail, err := p.FetchSongDetail(context.Background(), "Guns N' Roses & Co", "a/b?c=d#e")
+	if err != nil {
+		t.Fatalf("FetchSongDetail: %v", err)
+	}
+	if group != "Guns N' Roses & Co" || song != "a/b?c=d#e" {
+		t.Errorf("got group %q, song %q, want them unchanged (raw query %q)", group, song, rawQuery)
+	}
+	if detail.ReleaseDate != "16.07.2006" || detail.Text != "Ooh baby" || detail.Link != "https://example.com" {
+		t.Errorf("got detail %+v", detail)
+	}
+}
+
+func TestFetchSongDetailRetries(t *testing.T) {
+	var calls atomic.Int32
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	if _, err := p.FetchSongDetail(context.Background(), "Muse", "Uprising"); err != nil {
+		t.Fatalf("FetchSongDetail: %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("got %d calls, want 3", got)
+	}
+}
+
+func TestFetchSongDetailGivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	if _, err := p.FetchSongDetail(context.Background(), "Muse", "Uprising"); err == nil {
+		t.Fatal("got no error, want one")
+	}
+	if got, want := calls.Load(), int32(p.cfg.MaxRetries+1); got != want {
+		t.Errorf("got %d calls, want %d", got, want)
+	}
+}
+
+func TestFetchSongDetailDoesNotRetryClientErrors(t *testing.T) {
+	for _, tc := range []struct {
+		status int
+		want   error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusBadRequest, nil},
+	} {
+		var calls atomic.Int32
+		p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(tc.status)
+		})
+
+		_, err := p.FetchSongDetail(context.Background(), "Muse", "Uprising")
+		if err == nil || tc.want != nil && !errors.Is(err, tc.want) {
+			t.Errorf("status %d: got error %v, want %v", tc.status, err, tc.want)
+		}
+		if got := calls.Load(); got != 1 {
+			t.Errorf("status %d: got %d calls, want 1", tc.status, got)
+		}
+	}
+}
+
+func TestFetchSongDetailNotFoundKeepsCircuitClosed(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	for i := 0; i < p.cfg.BreakerThreshold+1; i++ {
+		if _, err := p.FetchSongDetail(context.Background(), "Muse", "Uprising"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("call %d: got error %v, want ErrNotFound", i, err)
+		}
+	}
+}
+
+func TestFetchSongDetailShortCircuits(t *testing.T) {
+	var calls atomic.Int32
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	p.cfg.MaxRetries = 0
+
+	for i := 0; i < p.cfg.BreakerThreshold; i++ {
+		if _, err := p.FetchSongDetail(context.Background(), "Muse", "Uprising"); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d: got error %v, want an upstream error", i, err)
+		}
+	}
+
+	_, err := p.FetchSongDetail(context.Background(), "Muse", "Uprising")
+	var open *CircuitOpenError
+	if !errors.As(err, &open) || !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got error %v, want CircuitOpenError", err)
+	}
+	if open.RetryAfter <= 0 || open.RetryAfter > p.cfg.BreakerCooldown {
+		t.Errorf("got RetryAfter %v, want within (0, %v]", open.RetryAfter, p.cfg.BreakerCooldown)
+	}
+	if got := calls.Load(); got != int32(p.cfg.BreakerThreshold) {
+		t.Errorf("got %d calls, want %d", got, p.cfg.BreakerThreshold)
+	}
+}
@@ -0,0 +1,172 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+type AlbumRepository struct {
+	*Storage
+}
+
+func NewAlbumRepository(storage *Storage) *AlbumRepository {
+	return &AlbumRepository{Storage: storage}
+}
+
+func (m *AlbumRepository) Add(_ context.Context, input *models.NewAlbumRequest) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.groups[input.GroupID]; !ok {
+		return 0, models.ErrGroupNotFound
+	}
+
+	if m.albumByTitle(input.GroupID, input.Title) >= 0 {
+		return 0, models.ErrAlbumExists
+	}
+
+	tracks, err := m.newTracks(input.Tracks)
+	if err != nil {
+		return 0, err
+	}
+
+	m.lastAlbumID++
+	id := m.lastAlbumID
+	now := timestamp()
+
+	m.albums[id] = models.Album{
+		ID:          id,
+		GroupID:     input.GroupID,
+		Title:       input.Title,
+		ReleaseDate: input.ReleaseDate,
+		CoverLink:   input.CoverLink,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	m.tracks[id] = tracks
+
+	return id, nil
+}
+
+func (m *AlbumRepository) Delete(_ context.Context, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.albums, id)
+	delete(m.tracks, id)
+	return nil
+}
+
+func (m *AlbumRepository) Edit(_ context.Context, id int, input *models.EditAlbumRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	album, ok := m.albums[id]
+	if !ok {
+		return nil
+	}
+
+	if input.Title != nil {
+		if other := m.albumByTitle(album.GroupID, *input.Title); other >= 0 && other != id {
+			return models.ErrAlbumExists
+		}
+		album.Title = *input.Title
+	}
+	if input.ReleaseDate != nil {
+		album.ReleaseDate = *input.ReleaseDate
+	}
+	if input.CoverLink != nil {
+		album.CoverLink = *input.CoverLink
+	}
+
+	if input.Tracks != nil {
+		tracks, err := m.newTracks(*input.Tracks)
+		if err != nil {
+			return err
+		}
+		m.tracks[id] = tracks
+	}
+
+	album.UpdatedAt = timestamp()
+	m.albums[id] = album
+
+	return nil
+}
+
+func (m *AlbumRepository) GetAlbum(_ context.Context, id int) (*models.Album, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	album, ok := m.albums[id]
+	if !ok {
+		return nil, nil
+	}
+
+	album.GroupName = m.groups[album.GroupID].Name
+	for _, track := range m.tracks[id] {
+		song, ok := m.songs[track.SongID]
+		if !ok {
+			continue
+		}
+		track.SongName = song.SongName
+		album.Tracks = append(album.Tracks, track)
+	}
+
+	return &album, nil
+}
+
+func (m *AlbumRepository) GetAlbums(_ context.Context, filter *models.AlbumFilter, page, limit int) ([]models.Album, int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	matched := make([]models.Album, 0, len(m.albums))
+	for _, album := range m.albums {
+		if filter.GroupID != 0 && album.GroupID != filter.GroupID {
+			continue
+		}
+		if filter.Title != "" && !containsFold(album.Title, filter.Title) {
+			continue
+		}
+
+		album.GroupName = m.groups[album.GroupID].Name
+		matched = append(matched, album)
+	}
+
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ID < matched[j].ID })
+
+	return paginate(matched, (page-1)*limit, limit), len(matched), nil
+}
+
+func (m *AlbumRepository) AlbumExists(_ context.Context, id int) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, ok := m.albums[id]
+	return ok, nil
+}
+
+// albumByTitle returns the id of the group's album with the given title, or
+// -1. The caller must hold mu.
+func (m *AlbumRepository) albumByTitle(groupID int, title string) int {
+	for id, album := range m.albums {
+		if album.GroupID == groupID && album.Title == title {
+			return id
+		}
+	}
+	return -1
+}
+
+// newTracks numbers songIDs from 1 in the given order. The caller must hold
+// mu.
+func (m *AlbumRepository) newTracks(songIDs []int) ([]models.Track, error) {
+	tracks := make([]models.Track, 0, len(songIDs))
+	for i, songID := range songIDs {
+		if _, ok := m.songs[songID]; !ok {
+			return nil, models.ErrSongNotFound
+		}
+		tracks = append(tracks, models.Track{Number: i + 1, SongID: songID})
+	}
+	return tracks, nil
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+type APIKeyRepository struct {
+	*Storage
+}
+
+func NewAPIKeyRepository(storage *Storage) *APIKeyRepository {
+	return &APIKeyRepository{Storage: storage}
+}
+
+func (m *APIKeyRepository) AddAPIKey(_ context.Context, key *models.APIKey) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lastAPIKeyID++
+	stored := *key
+	stored.ID = m.lastAPIKeyID
+	stored.Scopes = append(models.Scopes(nil), key.Scopes...)
+	stored.CreatedAt = timestamp()
+	stored.RevokedAt = nil
+	m.apiKeys[stored.ID] = stored
+
+	return stored.ID, nil
+}
+
+func (m *APIKeyRepository) GetAPIKeys(_ context.Context) ([]models.APIKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := make([]models.APIKey, 0, len(m.apiKeys))
+	for _, key := range m.apiKeys {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].ID < keys[j].ID
+	})
+
+	return keys, nil
+}
+
+func (m *APIKeyRepository) GetAPIKeyByHash(_ context.Context, hash string) (*models.APIKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, key := range m.apiKeys {
+		if key.KeyHash == hash {
+			return &key, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *APIKeyRepository) RevokeAPIKey(_ context.Context, id int, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key, ok := m.apiKeys[id]
+	if !ok {
+		return models.ErrAPIKeyNotFound
+	}
+
+	if key.RevokedAt == nil {
+		revokedAt := now.UTC().Format(time.RFC3339Nano)
+		key.RevokedAt = &revokedAt
+		m.apiKeys[id] = key
+	}
+
+	return nil
+}
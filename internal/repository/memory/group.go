@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+type GroupRepository struct {
+	*Storage
+}
+
+func NewGroupRepository(storage *Storage) *GroupRepository {
+	return &GroupRepository{Storage: storage}
+}
+
+func (m *GroupRepository) Add(_ context.Context, name string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.groupByName(name) >= 0 {
+		return 0, models.ErrGroupExists
+	}
+
+	return m.resolveGroup(name), nil
+}
+
+func (m *GroupRepository) Delete(_ context.Context, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, song := range m.songs {
+		if song.GroupID == id {
+			return models.ErrGroupNotEmpty
+		}
+	}
+
+	for _, album := range m.albums {
+		if album.GroupID == id {
+			return models.ErrGroupNotEmpty
+		}
+	}
+
+	for _, song := range m.trash {
+		if song.GroupID == id {
+			return models.ErrGroupNotEmpty
+		}
+	}
+
+	delete(m.groups, id)
+	return nil
+}
+
+func (m *GroupRepository) Edit(_ context.Context, id int, input *models.EditGroupRequest) error {
+	if input.Name == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	group, ok := m.groups[id]
+	if !ok {
+		return nil
+	}
+
+	if other := m.groupByName(*input.Name); other >= 0 && other != id {
+		return models.ErrGroupExists
+	}
+
+	group.Name = *input.Name
+	group.UpdatedAt = timestamp()
+	m.groups[id] = group
+
+	for songID, song := range m.songs {
+		if song.GroupID == id {
+			m.touchSong(songID)
+		}
+	}
+	for songID, song := range m.trash {
+		if song.GroupID == id {
+			song.UpdatedAt = timestamp()
+			song.Version++
+			m.trash[songID] = song
+		}
+	}
+
+	return nil
+}
+
+func (m *GroupRepository) GetGroup(_ context.Context, id int) (*models.Group, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	group, ok := m.groups[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return &group, nil
+}
+
+func (m *GroupRepository) GetGroups(_ context.Context, name string, page, limit int) ([]models.Group, int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	matched := make([]models.Group, 0, len(m.groups))
+	for _, group := range m.groups {
+		if name == "" || containsFold(group.Name, name) {
+			matched = append(matched, group)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].Name != matched[j].Name {
+			return matched[i].Name < matched[j].Name
+		}
+		return matched[i].ID < matched[j].ID
+	})
+
+	return paginate(matched, (page-1)*limit, limit), len(matched), nil
+}
+
+func (m *GroupRepository) GetGroupSongs(_ context.Context, id, page, limit int) ([]models.Song, int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var songs []models.Song
+	for songID, song := range m.songs {
+		if song.GroupID == id {
+			song, _ = m.song(songID)
+			songs = append(songs, song)
+		}
+	}
+
+	sort.Slice(songs, func(i, j int) bool { return songs[i].ID < songs[j].ID })
+
+	return paginate(songs, (page-1)*limit, limit), len(songs), nil
+}
+
+func (m *GroupRepository) GroupExists(_ context.Context, id int) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, ok := m.groups[id]
+	return ok, nil
+}
+
+// groupByName returns the id of the group called name, or -1. The caller
+// must hold mu.
+func (m *GroupRepository) groupByName(name string) int {
+	for id, group := range m.groups {
+		if group.Name == name {
+			return id
+		}
+	}
+	return -1
+}
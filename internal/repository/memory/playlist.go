@@ -0,0 +1,207 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+type PlaylistRepository struct {
+	*Storage
+}
+
+func NewPlaylistRepository(storage *Storage) *PlaylistRepository {
+	return &PlaylistRepository{Storage: storage}
+}
+
+func (m *PlaylistRepository) Add(_ context.Context, userId int, name string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lastPlaylistID++
+	id := m.lastPlaylistID
+	now := timestamp()
+
+	m.playlists[id] = models.Playlist{
+		ID:        id,
+		UserID:    userId,
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	return id, nil
+}
+
+func (m *PlaylistRepository) Delete(_ context.Context, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.playlists, id)
+	delete(m.items, id)
+	return nil
+}
+
+func (m *PlaylistRepository) Edit(_ context.Context, id int, input *models.EditPlaylistRequest) error {
+	if input.Name == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if playlist, ok := m.playlists[id]; ok {
+		playlist.Name = *input.Name
+		playlist.UpdatedAt = timestamp()
+		m.playlists[id] = playlist
+	}
+
+	return nil
+}
+
+func (m *PlaylistRepository) GetPlaylist(_ context.Context, userId, id int) (*models.Playlist, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	playlist, ok := m.playlists[id]
+	if !ok || playlist.UserID != userId {
+		return nil, nil
+	}
+
+	return &playlist, nil
+}
+
+func (m *PlaylistRepository) GetPlaylists(_ context.Context, userId, page, limit int) ([]models.Playlist, int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	playlists := make([]models.Playlist, 0, len(m.playlists))
+	for _, playlist := range m.playlists {
+		if playlist.UserID == userId {
+			playlists = append(playlists, playlist)
+		}
+	}
+
+	sort.Slice(playlists, func(i, j int) bool { return playlists[i].ID < playlists[j].ID })
+
+	return paginate(playlists, (page-1)*limit, limit), len(playlists), nil
+}
+
+func (m *PlaylistRepository) AddItem(_ context.Context, playlistId, songId int) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.songs[songId]; !ok {
+		return 0, models.ErrSongNotFound
+	}
+
+	items := m.items[playlistId]
+
+	sortKey := 1
+	if len(items) > 0 {
+		sortKey = items[len(items)-1].SortKey + 1
+	}
+
+	m.lastItemID++
+	item := models.PlaylistItem{ItemID: m.lastItemID, SortKey: sortKey}
+	item.ID = songId
+
+	m.items[playlistId] = append(items, item)
+	m.touchPlaylist(playlistId)
+
+	visible, _ := m.visibleItems(playlistId)
+	return len(visible), nil
+}
+
+func (m *PlaylistRepository) RemoveItem(_ context.Context, playlistId, position int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	visible, slots := m.visibleItems(playlistId)
+	if position < 1 || position > len(visible) {
+		return models.ErrPlaylistItemNotFound
+	}
+
+	slot := slots[position-1]
+	m.items[playlistId] = slices.Delete(m.items[playlistId], slot, slot+1)
+	m.touchPlaylist(playlistId)
+
+	return nil
+}
+
+func (m *PlaylistRepository) MoveItem(_ context.Context, playlistId, from, to int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	visible, slots := m.visibleItems(playlistId)
+	if from < 1 || from > len(visible) || to < 1 || to > len(visible) {
+		return models.ErrPlaylistItemNotFound
+	}
+
+	moved := visible[from-1]
+	visible = slices.Insert(slices.Delete(visible, from-1, from), to-1, moved)
+
+	// Items of songs in the trash keep their places.
+	items := m.items[playlistId]
+	for i, slot := range slots {
+		items[slot] = visible[i]
+	}
+
+	// Renumber like the SQL repositories do.
+	for i := range items {
+		items[i].SortKey = i + 1
+	}
+
+	m.items[playlistId] = items
+	m.touchPlaylist(playlistId)
+
+	return nil
+}
+
+func (m *PlaylistRepository) GetItems(_ context.Context, playlistId int, page *models.Page) ([]models.PlaylistItem, int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stored, _ := m.visibleItems(playlistId)
+	items := make([]models.PlaylistItem, 0, len(stored))
+	for i, item := range stored {
+		item.Song, _ = m.song(item.ID)
+		item.Position = i + 1
+		items = append(items, item)
+	}
+
+	if page.Cursor == nil {
+		return paginate(items, page.Offset, page.Limit), len(items), nil
+	}
+
+	boundary, err := strconv.Atoi(page.Cursor.Key)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid playlist cursor key %q: %w", page.Cursor.Key, err)
+	}
+
+	result := seek(items, page, func(i *models.PlaylistItem) int { return cmp.Compare(i.SortKey, boundary) })
+
+	return result, len(items), nil
+}
+
+func (m *PlaylistRepository) PlaylistExists(_ context.Context, userId, id int) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	playlist, ok := m.playlists[id]
+	return ok && playlist.UserID == userId, nil
+}
+
+// touchPlaylist bumps the playlist's updated_at. The caller must hold mu for
+// writing.
+func (m *PlaylistRepository) touchPlaylist(id int) {
+	if playlist, ok := m.playlists[id]; ok {
+		playlist.UpdatedAt = timestamp()
+		m.playlists[id] = playlist
+	}
+}
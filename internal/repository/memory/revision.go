@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+func (m *SongRepository) GetRevisions(_ context.Context, songId, page, limit int) ([]models.SongRevision, int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	revisions := slices.Clone(m.revisions[songId])
+	slices.Reverse(revisions)
+
+	return paginate(revisions, (page-1)*limit, limit), len(revisions), nil
+}
+
+func (m *SongRepository) GetRevision(_ context.Context, songId, revision int) (*models.SongRevision, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.revision(songId, revision)
+}
+
+func (m *SongRepository) RestoreRevision(ctx context.Context, songId, revision int) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	song, ok := m.songs[songId]
+	if !ok {
+		return 0, models.ErrSongNotFound
+	}
+
+	target, err := m.revision(songId, revision)
+	if err != nil {
+		return 0, err
+	}
+
+	state := target.State()
+
+	if m.songExists(state.GroupName, state.SongName, songId) {
+		return 0, models.ErrSongExists
+	}
+
+	before := m.snapshot(songId)
+
+	song.GroupID = m.resolveGroup(state.GroupName)
+	song.SongName = state.SongName
+	song.ReleaseDate = state.ReleaseDate
+	song.Link = state.Link
+	m.songs[songId] = song
+
+	verses := make([]models.Verse, 0, len(state.Verses))
+	for _, text := range state.Verses {
+		m.lastVerseID++
+		verses = append(verses, models.Verse{Id: m.lastVerseID, SongId: songId, Text: text})
+	}
+	m.renumberVerses(songId, verses)
+	m.touchSong(songId)
+
+	return m.recordRevision(ctx, songId, models.RevisionRollback, before), nil
+}
+
+// revision returns a copy of a stored revision. The caller must hold mu.
+func (s *Storage) revision(songId, revision int) (*models.SongRevision, error) {
+	revisions := s.revisions[songId]
+	if revision < 1 || revision > len(revisions) {
+		return nil, models.ErrRevisionNotFound
+	}
+
+	rev := revisions[revision-1]
+	return &rev, nil
+}
+
+// snapshot returns the current state of a song, in the trash or not, for
+// the revisions that record its changes. The caller must hold mu.
+func (s *Storage) snapshot(songId int) *models.SongSnapshot {
+	song, ok := s.songs[songId]
+	if !ok {
+		song = s.trash[songId]
+	}
+
+	verses := slices.Clone(s.verses[songId])
+	sort.Slice(verses, func(i, j int) bool { return verses[i].Index < verses[j].Index })
+
+	texts := make([]string, 0, len(verses))
+	for _, verse := range verses {
+		texts = append(texts, verse.Text)
+	}
+
+	return &models.SongSnapshot{
+		GroupName:   s.groups[song.GroupID].Name,
+		SongName:    song.SongName,
+		ReleaseDate: song.ReleaseDate,
+		Link:        song.Link,
+		Verses:      texts,
+	}
+}
+
+// recordRevision stores the change of a song from before to its current
+// state and returns the new revision number. The caller must hold mu for
+// writing.
+func (s *Storage) recordRevision(ctx context.Context, songId int, action string, before *models.SongSnapshot) int {
+	return s.addRevision(ctx, songId, action, before, s.snapshot(songId))
+}
+
+// addRevision stores a revision of a song, made by the actor of ctx, and
+// returns its number. The caller must hold mu for writing.
+func (s *Storage) addRevision(ctx context.Context, songId int, action string, before, after *models.SongSnapshot) int {
+	revision := len(s.revisions[songId]) + 1
+	s.revisions[songId] = append(s.revisions[songId], models.SongRevision{
+		SongID:    songId,
+		Revision:  revision,
+		Action:    action,
+		Actor:     models.ActorFrom(ctx),
+		CreatedAt: timestamp(),
+		Before:    before,
+		After:     after,
+	})
+
+	return revision
+}
@@ -0,0 +1,582 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+type SongRepository struct {
+	*Storage
+}
+
+func NewSongRepository(storage *Storage) *SongRepository {
+	return &SongRepository{Storage: storage}
+}
+
+func (m *SongRepository) Add(ctx context.Context, song *models.Song) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.songExists(song.GroupName, song.SongName, 0) {
+		return 0, models.ErrSongExists
+	}
+
+	return m.insertSong(ctx, song), nil
+}
+
+func (m *SongRepository) ImportSongs(ctx context.Context, songs []models.Song) ([]int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids := make([]int, len(songs))
+	for i := range songs {
+		if !m.songExists(songs[i].GroupName, songs[i].SongName, 0) {
+			ids[i] = m.insertSong(ctx, &songs[i])
+		}
+	}
+
+	return ids, nil
+}
+
+// songExists reports whether the group already has a song with the name,
+// other than the song except. The caller must hold mu.
+func (m *SongRepository) songExists(groupName, songName string, except int) bool {
+	for id := range m.songs {
+		if existing, _ := m.song(id); id != except && existing.GroupName == groupName && existing.SongName == songName {
+			return true
+		}
+	}
+	return false
+}
+
+// insertSong adds the song, its group if needed and its verses. The caller
+// must hold mu for writing.
+func (m *SongRepository) insertSong(ctx context.Context, song *models.Song) int {
+	m.lastSongID++
+	id := m.lastSongID
+	now := timestamp()
+
+	m.songs[id] = models.Song{
+		ID:          id,
+		GroupID:     m.resolveGroup(song.GroupName),
+		SongName:    song.SongName,
+		ReleaseDate: song.ReleaseDate,
+		Link:        song.Link,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Version:     1,
+	}
+
+	texts := models.SplitVerses(song.Text)
+	verses := make([]models.Verse, 0, len(texts))
+	for index := range texts {
+		m.lastVerseID++
+		verses = append(verses, models.Verse{
+			Id:     m.lastVerseID,
+			SongId: id,
+			Index:  index + 1,
+			Text:   texts[index],
+		})
+	}
+	m.verses[id] = verses
+	m.recordRevision(ctx, id, models.RevisionCreate, nil)
+
+	return id
+}
+
+// Delete moves the song to the trash. Its verses, album tracks and playlist
+// items are kept for a restore; reads of albums and playlists skip them.
+func (m *SongRepository) Delete(ctx context.Context, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	song, ok := m.songs[id]
+	if !ok {
+		return nil
+	}
+
+	m.addRevision(ctx, id, models.RevisionDelete, m.snapshot(id), nil)
+
+	deletedAt := timestamp()
+	song.DeletedAt = &deletedAt
+	m.trash[id] = song
+	delete(m.songs, id)
+
+	return nil
+}
+
+func (m *SongRepository) Edit(ctx context.Context, id int, input *models.EditSongRequest, version int) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	verseIdx := -1
+	if input.Verse != nil {
+		verseIdx = m.verseIndex(id, input.Verse.Index)
+		if verseIdx < 0 {
+			return 0, models.ErrVerseNotFound
+		}
+	}
+
+	song, ok := m.songs[id]
+	if !ok {
+		return 0, models.ErrSongNotFound
+	}
+	if version != 0 && version != song.Version {
+		return 0, models.ErrVersionMismatch
+	}
+
+	groupName, songName := m.groups[song.GroupID].Name, song.SongName
+	if input.GroupName != nil {
+		groupName = *input.GroupName
+	}
+	if input.SongName != nil {
+		songName = *input.SongName
+	}
+	if m.songExists(groupName, songName, id) {
+		return 0, models.ErrSongExists
+	}
+
+	if input.GroupName == nil && input.SongName == nil && input.ReleaseDate == nil && input.Link == nil && input.Verse == nil {
+		return song.Version, nil
+	}
+
+	before := m.snapshot(id)
+
+	if input.GroupName != nil {
+		song.GroupID = m.resolveGroup(*input.GroupName)
+	}
+	if input.SongName != nil {
+		song.SongName = *input.SongName
+	}
+	if input.ReleaseDate != nil {
+		song.ReleaseDate = models.Date(*input.ReleaseDate)
+	}
+	if input.Link != nil {
+		song.Link = *input.Link
+	}
+	m.songs[id] = song
+
+	if verseIdx >= 0 {
+		m.verses[id][verseIdx].Text = input.Verse.Text
+	}
+
+	m.touchSong(id)
+	m.recordRevision(ctx, id, models.RevisionEdit, before)
+
+	return m.songs[id].Version, nil
+}
+
+func (m *SongRepository) GetSong(_ context.Context, id int) (*models.Song, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	song, ok := m.song(id)
+	if !ok {
+		return nil, models.ErrSongNotFound
+	}
+
+	verses := slices.Clone(m.verses[id])
+	sort.Slice(verses, func(i, j int) bool { return verses[i].Index < verses[j].Index })
+	song.Text = models.JoinVerses(verses)
+
+	return &song, nil
+}
+
+func (m *SongRepository) GetSongs(_ context.Context, filter *models.SongFilter, page *models.Page) ([]models.Song, int, error) {
+	keyOf, err := songSortKey(filter.SortBy)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	matched := make([]models.Song, 0, len(m.songs))
+	for id := range m.songs {
+		s, _ := m.song(id)
+		if filter.AlbumID != 0 {
+			number := m.trackNumber(filter.AlbumID, id)
+			if number == 0 {
+				continue
+			}
+			s.TrackNumber = &number
+		}
+		if matchSong(&s, filter) {
+			matched = append(matched, s)
+		}
+	}
+
+	compare := func(a, b sortKey) int {
+		if filter.SortDesc {
+			return b.compare(a)
+		}
+		return a.compare(b)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return compare(keyOf(&matched[i]), keyOf(&matched[j])) < 0
+	})
+
+	if page.Cursor == nil {
+		return paginate(matched, page.Offset, page.Limit), len(matched), nil
+	}
+
+	boundary, err := cursorSortKey(filter.SortBy, page.Cursor)
+	if err != nil {
+		return nil, 0, err
+	}
+	songs := seek(matched, page, func(s *models.Song) int { return compare(keyOf(s), boundary) })
+
+	return songs, len(matched), nil
+}
+
+func (m *SongRepository) ExportSongs(ctx context.Context, filter *models.SongFilter, emit func(*models.SongExport) error) error {
+	songs, _, err := m.GetSongs(ctx, filter, &models.Page{Limit: math.MaxInt})
+	if err != nil {
+		return err
+	}
+
+	exports := make([]models.SongExport, len(songs))
+
+	m.mu.RLock()
+	for i := range songs {
+		verses := slices.Clone(m.verses[songs[i].ID])
+		if verses == nil {
+			verses = []models.Verse{}
+		}
+		sort.Slice(verses, func(a, b int) bool { return verses[a].Index < verses[b].Index })
+
+		exports[i] = models.SongExport{Song: songs[i], Verses: verses}
+		exports[i].Text = models.JoinVerses(verses)
+	}
+	m.mu.RUnlock()
+
+	for i := range exports {
+		if err = emit(&exports[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *SongRepository) GetSongVerses(_ context.Context, songId int, page *models.Page) ([]models.Verse, int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// The verses of songs in the trash are kept for a restore but hidden.
+	var verses []models.Verse
+	if _, ok := m.songs[songId]; ok {
+		verses = m.verses[songId]
+	}
+	sorted := make([]models.Verse, len(verses))
+	copy(sorted, verses)
+
+	keyOf := func(v *models.Verse) sortKey { return sortKey{index: v.Index, id: v.Id} }
+	sort.Slice(sorted, func(i, j int) bool { return keyOf(&sorted[i]).compare(keyOf(&sorted[j])) < 0 })
+
+	if page.Cursor == nil {
+		return paginate(sorted, page.Offset, page.Limit), len(sorted), nil
+	}
+
+	index, err := strconv.Atoi(page.Cursor.Key)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid verse cursor key %q: %w", page.Cursor.Key, err)
+	}
+
+	boundary := sortKey{index: index, id: page.Cursor.ID}
+	result := seek(sorted, page, func(v *models.Verse) int { return keyOf(v).compare(boundary) })
+
+	return result, len(sorted), nil
+}
+
+func (m *SongRepository) SearchVerses(_ context.Context, text string, page, limit int) ([]models.VerseSearchResult, int, error) {
+	terms := make(map[string]bool)
+	for _, word := range words(text) {
+		terms[strings.ToLower(text[word[0]:word[1]])] = true
+	}
+	if len(terms) == 0 {
+		return nil, 0, nil
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var results []models.VerseSearchResult
+	for songID, verses := range m.verses {
+		song, ok := m.song(songID)
+		if !ok {
+			continue
+		}
+
+		for _, verse := range verses {
+			snippet, hits, matched := highlight(verse.Text, terms)
+			if matched != len(terms) {
+				continue
+			}
+
+			results = append(results, models.VerseSearchResult{
+				SongID:     songID,
+				GroupName:  song.GroupName,
+				SongName:   song.SongName,
+				VerseIndex: verse.Index,
+				Snippet:    snippet,
+				Rank:       float64(hits) / float64(len(words(verse.Text))),
+			})
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Rank != b.Rank {
+			return a.Rank > b.Rank
+		}
+		if a.SongID != b.SongID {
+			return a.SongID < b.SongID
+		}
+		return a.VerseIndex < b.VerseIndex
+	})
+
+	return paginate(results, (page-1)*limit, limit), len(results), nil
+}
+
+func (m *SongRepository) SongExists(_ context.Context, id int) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, ok := m.songs[id]
+	return ok, nil
+}
+
+func (m *SongRepository) VerseExists(_ context.Context, songId, index int) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, ok := m.songs[songId]; !ok {
+		return false, nil
+	}
+	return m.verseIndex(songId, index) >= 0, nil
+}
+
+// verseIndex returns the position of the verse in m.verses[songId], or -1.
+// The caller must hold m.mu.
+func (m *SongRepository) verseIndex(songId, index int) int {
+	for i, verse := range m.verses[songId] {
+		if verse.Index == index {
+			return i
+		}
+	}
+	return -1
+}
+
+// paginate mirrors LIMIT/OFFSET: offsets past the end yield nil, just like
+// an empty sqlx.Select result.
+func paginate[T any](items []T, offset, limit int) []T {
+	if offset < 0 || offset >= len(items) {
+		return nil
+	}
+
+	end := offset + limit
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return items[offset:end]
+}
+
+// seek mirrors a keyset query over sorted items: it returns up to page.Limit
+// items right after the cursor, or right before it for a backward cursor.
+// cmp reports the position of an item relative to the cursor.
+func seek[T any](sorted []T, page *models.Page, cmp func(*T) int) []T {
+	var window []T
+	for i := range sorted {
+		if c := cmp(&sorted[i]); (c > 0) != page.Cursor.Backward && c != 0 {
+			window = append(window, sorted[i])
+		}
+	}
+
+	if len(window) <= page.Limit {
+		return window
+	}
+	if page.Cursor.Backward {
+		return window[len(window)-page.Limit:]
+	}
+	return window[:page.Limit]
+}
+
+func matchSong(s *models.Song, filter *models.SongFilter) bool {
+	if filter.Group != "" && !containsFold(s.GroupName, filter.Group) {
+		return false
+	}
+
+	if filter.Song != "" && !containsFold(s.SongName, filter.Song) {
+		return false
+	}
+
+	release := string(s.ReleaseDate)
+	if filter.ReleasedAfter != nil && (release == "" || release < filter.ReleasedAfter.Format(models.DateLayout)) {
+		return false
+	}
+
+	if filter.ReleasedBefore != nil && (release == "" || release > filter.ReleasedBefore.Format(models.DateLayout)) {
+		return false
+	}
+
+	if filter.CreatedAfter != nil || filter.CreatedBefore != nil {
+		created, err := time.Parse(time.RFC3339Nano, s.CreatedAt)
+		if err != nil {
+			return false
+		}
+		if filter.CreatedAfter != nil && created.Before(*filter.CreatedAfter) {
+			return false
+		}
+		if filter.CreatedBefore != nil && created.After(*filter.CreatedBefore) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sortKey is the (sort column, id) tuple rows are ordered by. Songs use
+// value, verses and track numbers use index.
+type sortKey struct {
+	value string
+	index int
+	id    int
+}
+
+func (k sortKey) compare(other sortKey) int {
+	if c := strings.Compare(k.value, other.value); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(k.index, other.index); c != 0 {
+		return c
+	}
+	return cmp.Compare(k.id, other.id)
+}
+
+// songSortKey orders songs like the SQL repositories: by the requested
+// column, then by id.
+func songSortKey(sortBy string) (func(s *models.Song) sortKey, error) {
+	if sortBy != "" && !models.SongSortColumns[sortBy] {
+		return nil, fmt.Errorf("unknown sort column %q", sortBy)
+	}
+
+	if sortBy == "track_number" {
+		return func(s *models.Song) sortKey {
+			if s.TrackNumber == nil {
+				return sortKey{id: s.ID}
+			}
+			return sortKey{index: *s.TrackNumber, id: s.ID}
+		}, nil
+	}
+
+	return func(s *models.Song) sortKey {
+		return sortKey{value: normalizeSortValue(sortBy, s.SortValue(sortBy)), id: s.ID}
+	}, nil
+}
+
+// cursorSortKey is the sort key of the row a song cursor points at.
+func cursorSortKey(sortBy string, c *models.Cursor) (sortKey, error) {
+	if sortBy != "track_number" {
+		return sortKey{value: normalizeSortValue(sortBy, c.Key), id: c.ID}, nil
+	}
+
+	number, err := strconv.Atoi(c.Key)
+	if err != nil {
+		return sortKey{}, fmt.Errorf("invalid track cursor key %q: %w", c.Key, err)
+	}
+	return sortKey{index: number, id: c.ID}, nil
+}
+
+func normalizeSortValue(sortBy, value string) string {
+	if sortBy == "created_at" || sortBy == "updated_at" {
+		return timeKey(value)
+	}
+	return value
+}
+
+// timeKey makes an RFC 3339 timestamp sortable as a string; RFC3339Nano
+// drops trailing zeros, so the raw values do not compare correctly.
+func timeKey(value string) string {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return value
+	}
+	return t.UTC().Format("2006-01-02T15:04:05.000000000")
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// words returns the [start, end) byte offsets of every run of letters and
+// digits in text.
+func words(text string) [][2]int {
+	var (
+		spans [][2]int
+		start = -1
+	)
+
+	for i, r := range text {
+		inWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case inWord && start < 0:
+			start = i
+		case !inWord && start >= 0:
+			spans = append(spans, [2]int{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, [2]int{start, len(text)})
+	}
+
+	return spans
+}
+
+// highlight wraps every word of text found in terms with <b></b>. It returns
+// the marked-up text, the number of highlighted words and how many distinct
+// terms were found.
+func highlight(text string, terms map[string]bool) (string, int, int) {
+	var (
+		sb    strings.Builder
+		last  int
+		hits  int
+		found = make(map[string]bool)
+	)
+
+	for _, span := range words(text) {
+		word := strings.ToLower(text[span[0]:span[1]])
+		if !terms[word] {
+			continue
+		}
+
+		sb.WriteString(text[last:span[0]])
+		sb.WriteString("<b>")
+		sb.WriteString(text[span[0]:span[1]])
+		sb.WriteString("</b>")
+		last = span[1]
+
+		hits++
+		found[word] = true
+	}
+	sb.WriteString(text[last:])
+
+	return sb.String(), hits, len(found)
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
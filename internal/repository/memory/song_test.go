@@ -0,0 +1,47 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/LionJr/music-library/internal/repository/memory"
+	"github.com/LionJr/music-library/internal/repository/repotest"
+	"github.com/LionJr/music-library/internal/service/song"
+)
+
+func TestSongRepository(t *testing.T) {
+	repotest.RunSongRepo(t, func(t *testing.T) song.Repo {
+		return memory.NewSongRepository(memory.NewStorage())
+	})
+}
+
+func newRepos(t *testing.T) *repotest.Repos {
+	storage := memory.NewStorage()
+	return &repotest.Repos{
+		Songs:     memory.NewSongRepository(storage),
+		Groups:    memory.NewGroupRepository(storage),
+		Albums:    memory.NewAlbumRepository(storage),
+		Playlists: memory.NewPlaylistRepository(storage),
+		Users:     memory.NewUserRepository(storage),
+		Keys:      memory.NewAPIKeyRepository(storage),
+	}
+}
+
+func TestGroupRepository(t *testing.T) {
+	repotest.RunGroupRepo(t, newRepos)
+}
+
+func TestAlbumRepository(t *testing.T) {
+	repotest.RunAlbumRepo(t, newRepos)
+}
+
+func TestPlaylistRepository(t *testing.T) {
+	repotest.RunPlaylistRepo(t, newRepos)
+}
+
+func TestUserRepository(t *testing.T) {
+	repotest.RunUserRepo(t, newRepos)
+}
+
+func TestAPIKeyRepository(t *testing.T) {
+	repotest.RunAPIKeyRepo(t, newRepos)
+}
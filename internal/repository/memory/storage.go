@@ -0,0 +1,150 @@
+package memory
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+// Storage is the in-memory counterpart of a database: the repositories built
+// on the same Storage share its data and its lock.
+type Storage struct {
+	mu    sync.RWMutex
+	songs map[int]models.Song
+	// trash holds deleted songs, with DeletedAt set, until they are restored
+	// or purged. Their verses stay in verses.
+	trash     map[int]models.Song
+	verses    map[int][]models.Verse
+	groups    map[int]models.Group
+	albums    map[int]models.Album
+	tracks    map[int][]models.Track
+	playlists map[int]models.Playlist
+	// items holds playlist items in order, with only the item fields and
+	// the song id set. Like tracks, it keeps the entries of songs in the
+	// trash until they are purged.
+	items map[int][]models.PlaylistItem
+	// revisions holds the revisions of each song, oldest first, so that
+	// revision n is at index n-1.
+	revisions      map[int][]models.SongRevision
+	users          map[int]models.User
+	refreshTokens  map[string]models.RefreshToken
+	apiKeys        map[int]models.APIKey
+	lastSongID     int
+	lastVerseID    int
+	lastGroupID    int
+	lastAlbumID    int
+	lastPlaylistID int
+	lastItemID     int
+	lastUserID     int
+	lastTokenID    int
+	lastAPIKeyID   int
+}
+
+func NewStorage() *Storage {
+	return &Storage{
+		songs:  make(map[int]models.Song),
+		trash:  make(map[int]models.Song),
+		verses: make(map[int][]models.Verse),
+		groups: make(map[int]models.Group),
+		albums: make(map[int]models.Album),
+		tracks: make(map[int][]models.Track),
+
+		playlists: make(map[int]models.Playlist),
+		items:     make(map[int][]models.PlaylistItem),
+		revisions: make(map[int][]models.SongRevision),
+
+		users:         make(map[int]models.User),
+		refreshTokens: make(map[string]models.RefreshToken),
+		apiKeys:       make(map[int]models.APIKey),
+	}
+}
+
+// song returns the stored song with its group name filled in. The caller
+// must hold mu.
+func (s *Storage) song(id int) (models.Song, bool) {
+	song, ok := s.songs[id]
+	if !ok {
+		return song, false
+	}
+
+	song.GroupName = s.groups[song.GroupID].Name
+	return song, true
+}
+
+// touchSong bumps the updated_at and version of a song after a change. The
+// caller must hold mu for writing.
+func (s *Storage) touchSong(id int) {
+	song := s.songs[id]
+	song.UpdatedAt = timestamp()
+	song.Version++
+	s.songs[id] = song
+}
+
+// purgeSong removes a song in the trash for good, together with its verses,
+// revisions, album tracks and playlist items. The caller must hold mu for
+// writing.
+func (s *Storage) purgeSong(id int) {
+	delete(s.trash, id)
+	delete(s.verses, id)
+	delete(s.revisions, id)
+
+	for albumID, tracks := range s.tracks {
+		s.tracks[albumID] = slices.DeleteFunc(tracks, func(t models.Track) bool { return t.SongID == id })
+	}
+
+	for playlistID, items := range s.items {
+		s.items[playlistID] = slices.DeleteFunc(items, func(i models.PlaylistItem) bool { return i.ID == id })
+	}
+}
+
+// visibleItems returns the items of a playlist whose songs are not in the
+// trash, together with their indexes in the stored items. The caller must
+// hold mu.
+func (s *Storage) visibleItems(playlistID int) ([]models.PlaylistItem, []int) {
+	var (
+		items []models.PlaylistItem
+		slots []int
+	)
+
+	for i, item := range s.items[playlistID] {
+		if _, ok := s.songs[item.ID]; ok {
+			items = append(items, item)
+			slots = append(slots, i)
+		}
+	}
+
+	return items, slots
+}
+
+// resolveGroup returns the id of the group with the given name, creating the
+// group if it does not exist yet. The caller must hold mu for writing.
+func (s *Storage) resolveGroup(name string) int {
+	for id, group := range s.groups {
+		if group.Name == name {
+			return id
+		}
+	}
+
+	s.lastGroupID++
+	now := timestamp()
+	s.groups[s.lastGroupID] = models.Group{
+		ID:        s.lastGroupID,
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	return s.lastGroupID
+}
+
+// trackNumber returns the number of the song on the album, or 0. The caller
+// must hold mu.
+func (s *Storage) trackNumber(albumID, songID int) int {
+	for _, track := range s.tracks[albumID] {
+		if track.SongID == songID {
+			return track.Number
+		}
+	}
+	return 0
+}
@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+func (m *SongRepository) GetTrash(_ context.Context, page, limit int) ([]models.Song, int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	songs := make([]models.Song, 0, len(m.trash))
+	for _, song := range m.trash {
+		song.GroupName = m.groups[song.GroupID].Name
+		songs = append(songs, song)
+	}
+
+	slices.SortFunc(songs, func(a, b models.Song) int {
+		return cmp.Or(cmp.Compare(timeKey(*b.DeletedAt), timeKey(*a.DeletedAt)), cmp.Compare(b.ID, a.ID))
+	})
+
+	return paginate(songs, (page-1)*limit, limit), len(songs), nil
+}
+
+func (m *SongRepository) RestoreSong(ctx context.Context, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	song, ok := m.trash[id]
+	if !ok {
+		return models.ErrSongNotFound
+	}
+
+	if m.songExists(m.groups[song.GroupID].Name, song.SongName, 0) {
+		return models.ErrSongExists
+	}
+
+	song.DeletedAt = nil
+	m.songs[id] = song
+	delete(m.trash, id)
+	m.recordRevision(ctx, id, models.RevisionRestore, nil)
+
+	return nil
+}
+
+func (m *SongRepository) PurgeTrash(_ context.Context, olderThan time.Duration) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cutoff := time.Now().Add(-olderThan)
+
+	purged := 0
+	for id, song := range m.trash {
+		deletedAt, err := time.Parse(time.RFC3339Nano, *song.DeletedAt)
+		if err != nil {
+			return purged, err
+		}
+
+		if deletedAt.Before(cutoff) {
+			m.purgeSong(id)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+type UserRepository struct {
+	*Storage
+}
+
+func NewUserRepository(storage *Storage) *UserRepository {
+	return &UserRepository{Storage: storage}
+}
+
+func (m *UserRepository) AddUser(_ context.Context, username, passwordHash string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, user := range m.users {
+		if user.Username == username {
+			return 0, models.ErrUserExists
+		}
+	}
+
+	m.lastUserID++
+	now := timestamp()
+	m.users[m.lastUserID] = models.User{
+		ID:           m.lastUserID,
+		Username:     username,
+		PasswordHash: passwordHash,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	return m.lastUserID, nil
+}
+
+func (m *UserRepository) GetUserByName(_ context.Context, username string) (*models.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, user := range m.users {
+		if user.Username == username {
+			return &user, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *UserRepository) AddRefreshToken(_ context.Context, token *models.RefreshToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.storeToken(token)
+	return nil
+}
+
+func (m *UserRepository) RotateRefreshToken(_ context.Context, oldHash string, next *models.RefreshToken, now time.Time) (*models.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, ok := m.refreshTokens[oldHash]
+	if !ok {
+		return nil, models.ErrInvalidRefreshToken
+	}
+
+	if current.RevokedAt != nil {
+		for hash, token := range m.refreshTokens {
+			if token.UserID == current.UserID && token.RevokedAt == nil {
+				token.RevokedAt = &now
+				m.refreshTokens[hash] = token
+			}
+		}
+		return nil, models.ErrRefreshTokenReused
+	}
+
+	if !now.Before(current.ExpiresAt) {
+		return nil, models.ErrInvalidRefreshToken
+	}
+
+	current.RevokedAt = &now
+	m.refreshTokens[oldHash] = current
+
+	next.UserID = current.UserID
+	m.storeToken(next)
+
+	user := m.users[current.UserID]
+	return &user, nil
+}
+
+func (m *UserRepository) RevokeRefreshToken(_ context.Context, hash string, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	token, ok := m.refreshTokens[hash]
+	if ok && token.RevokedAt == nil {
+		token.RevokedAt = &now
+		m.refreshTokens[hash] = token
+	}
+
+	return nil
+}
+
+// storeToken saves a copy of token under a new id. The caller must hold mu
+// for writing.
+func (m *UserRepository) storeToken(token *models.RefreshToken) {
+	m.lastTokenID++
+	stored := *token
+	stored.ID = m.lastTokenID
+	m.refreshTokens[stored.TokenHash] = stored
+}
@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+func (m *SongRepository) AddVerse(ctx context.Context, songId, position int, text string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	verses, err := m.sortedVerses(songId)
+	if err != nil {
+		return 0, err
+	}
+
+	if position == 0 {
+		position = len(verses) + 1
+	}
+	if position < 1 || position > len(verses)+1 {
+		return 0, models.ErrVerseNotFound
+	}
+
+	before := m.snapshot(songId)
+
+	m.lastVerseID++
+	verses = slices.Insert(verses, position-1, models.Verse{Id: m.lastVerseID, SongId: songId, Text: text})
+	m.renumberVerses(songId, verses)
+	m.touchSong(songId)
+	m.recordRevision(ctx, songId, models.RevisionAddVerse, before)
+
+	return position, nil
+}
+
+func (m *SongRepository) DeleteVerse(ctx context.Context, songId, index int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	verses, err := m.sortedVerses(songId)
+	if err != nil {
+		return err
+	}
+
+	if index < 1 || index > len(verses) {
+		return models.ErrVerseNotFound
+	}
+
+	before := m.snapshot(songId)
+
+	m.renumberVerses(songId, slices.Delete(verses, index-1, index))
+	m.touchSong(songId)
+	m.recordRevision(ctx, songId, models.RevisionDeleteVerse, before)
+
+	return nil
+}
+
+func (m *SongRepository) ReorderVerses(ctx context.Context, songId int, order []int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	verses, err := m.sortedVerses(songId)
+	if err != nil {
+		return err
+	}
+
+	if len(order) != len(verses) {
+		return models.ErrInvalidVerseOrder
+	}
+
+	seen := make(map[int]bool, len(order))
+	reordered := make([]models.Verse, 0, len(order))
+	for _, index := range order {
+		if index < 1 || index > len(verses) || seen[index] {
+			return models.ErrInvalidVerseOrder
+		}
+		seen[index] = true
+		reordered = append(reordered, verses[index-1])
+	}
+
+	before := m.snapshot(songId)
+
+	m.renumberVerses(songId, reordered)
+	m.touchSong(songId)
+	m.recordRevision(ctx, songId, models.RevisionReorderVerses, before)
+
+	return nil
+}
+
+func (m *SongRepository) ReplaceText(ctx context.Context, songId int, text string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.songs[songId]; !ok {
+		return 0, models.ErrSongNotFound
+	}
+
+	before := m.snapshot(songId)
+
+	texts := models.SplitVerses(text)
+	verses := make([]models.Verse, 0, len(texts))
+	for index := range texts {
+		m.lastVerseID++
+		verses = append(verses, models.Verse{Id: m.lastVerseID, SongId: songId, Text: texts[index]})
+	}
+	m.renumberVerses(songId, verses)
+	m.touchSong(songId)
+	m.recordRevision(ctx, songId, models.RevisionReplaceText, before)
+
+	return len(verses), nil
+}
+
+// sortedVerses returns a copy of the verses of the song in index order. The
+// caller must hold mu.
+func (m *SongRepository) sortedVerses(songId int) ([]models.Verse, error) {
+	if _, ok := m.songs[songId]; !ok {
+		return nil, models.ErrSongNotFound
+	}
+
+	verses := slices.Clone(m.verses[songId])
+	sort.Slice(verses, func(i, j int) bool { return verses[i].Index < verses[j].Index })
+
+	return verses, nil
+}
+
+// renumberVerses stores verses as the verses of the song, numbered from 1 in
+// slice order. The caller must hold mu for writing.
+func (m *SongRepository) renumberVerses(songId int, verses []models.Verse) {
+	for i := range verses {
+		verses[i].Index = i + 1
+	}
+	m.verses[songId] = verses
+}
@@ -0,0 +1,240 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+type AlbumRepository struct {
+	db *sqlx.DB
+}
+
+func NewAlbumRepository(db *sqlx.DB) *AlbumRepository {
+	return &AlbumRepository{db: db}
+}
+
+func (m *AlbumRepository) Add(ctx context.Context, input *models.NewAlbumRequest) (int, error) {
+	var id int
+
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return id, err
+	}
+
+	query := `INSERT INTO albums(group_id, title, release_date, cover_link) VALUES ($1, $2, $3, $4) RETURNING id`
+	err = tx.QueryRowContext(ctx, query,
+		input.GroupID,
+		input.Title,
+		input.ReleaseDate,
+		input.CoverLink,
+	).Scan(&id)
+	if err != nil {
+		_ = tx.Rollback()
+		switch {
+		case isPgError(err, uniqueViolation):
+			return id, models.ErrAlbumExists
+		case isPgError(err, foreignKeyViolation):
+			return id, models.ErrGroupNotFound
+		default:
+			return id, err
+		}
+	}
+
+	if err = insertTracks(ctx, tx, id, input.Tracks); err != nil {
+		_ = tx.Rollback()
+		return id, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return id, err
+	}
+
+	return id, nil
+}
+
+func (m *AlbumRepository) Delete(ctx context.Context, id int) error {
+	query := `DELETE 
+			  FROM albums 
+			  WHERE id = $1`
+	_, err := m.db.ExecContext(ctx, query, id)
+	return err
+}
+
+func (m *AlbumRepository) Edit(ctx context.Context, id int, input *models.EditAlbumRequest) error {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if input.Title != nil {
+		args = append(args, *input.Title)
+		conditions = append(conditions, fmt.Sprintf("title = $%d", len(args)))
+	}
+
+	if input.ReleaseDate != nil {
+		args = append(args, *input.ReleaseDate)
+		conditions = append(conditions, fmt.Sprintf("release_date = $%d", len(args)))
+	}
+
+	if input.CoverLink != nil {
+		args = append(args, *input.CoverLink)
+		conditions = append(conditions, fmt.Sprintf("cover_link = $%d", len(args)))
+	}
+
+	if len(args) == 0 && input.Tracks == nil {
+		return nil
+	}
+
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	conditions = append(conditions, "updated_at = NOW()")
+	args = append(args, id)
+	query := `UPDATE albums SET` + " " + strings.Join(conditions, ", ") + fmt.Sprintf(" WHERE id = $%d", len(args))
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		_ = tx.Rollback()
+		if isPgError(err, uniqueViolation) {
+			return models.ErrAlbumExists
+		}
+		return fmt.Errorf("failed to execute update query for album with id - %d: %w", id, err)
+	}
+
+	if input.Tracks != nil {
+		if _, err = tx.ExecContext(ctx, `DELETE FROM album_tracks WHERE album_id = $1`, id); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+
+		if err = insertTracks(ctx, tx, id, *input.Tracks); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (m *AlbumRepository) GetAlbum(ctx context.Context, id int) (*models.Album, error) {
+	var album models.Album
+
+	query := `SELECT a.id, a.group_id, g.name AS group_name, a.title, a.release_date,
+                     a.cover_link, a.created_at, a.updated_at
+              FROM albums AS a
+              JOIN groups AS g ON g.id = a.group_id
+              WHERE a.id = $1`
+
+	err := m.db.GetContext(ctx, &album, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tracksQuery := `SELECT t.track_number, t.song_id, s.song_name
+                    FROM album_tracks AS t
+                    JOIN songs AS s ON s.id = t.song_id
+                    WHERE t.album_id = $1 AND s.deleted_at IS NULL
+                    ORDER BY t.track_number`
+
+	err = m.db.SelectContext(ctx, &album.Tracks, tracksQuery, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &album, nil
+}
+
+func (m *AlbumRepository) GetAlbums(ctx context.Context, filter *models.AlbumFilter, page, limit int) ([]models.Album, int, error) {
+	var (
+		albums     []models.Album
+		conditions []string
+		args       []interface{}
+		totalCount int
+	)
+
+	if filter.GroupID != 0 {
+		args = append(args, filter.GroupID)
+		conditions = append(conditions, fmt.Sprintf("a.group_id = $%d", len(args)))
+	}
+
+	if filter.Title != "" {
+		args = append(args, escapeLike(filter.Title))
+		conditions = append(conditions, fmt.Sprintf("a.title ILIKE '%%' || $%d || '%%'", len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+
+	query := `SELECT a.id, a.group_id, g.name AS group_name, a.title, a.release_date,
+                     a.cover_link, a.created_at, a.updated_at
+              FROM albums AS a
+              JOIN groups AS g ON g.id = a.group_id` + where +
+		fmt.Sprintf(" ORDER BY a.id LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+
+	offset := (page - 1) * limit
+
+	err := m.db.SelectContext(ctx, &albums, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	countQuery := `SELECT COUNT(a.id) FROM albums AS a` + where
+
+	err = m.db.GetContext(ctx, &totalCount, countQuery, args...)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	return albums, totalCount, nil
+}
+
+func (m *AlbumRepository) AlbumExists(ctx context.Context, id int) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT id 
+    				 		FROM albums 
+    				 		WHERE id = $1)`
+	err := m.db.QueryRowContext(ctx, query, id).Scan(&exists)
+	return exists, err
+}
+
+// insertTracks numbers songIDs from 1 in the given order. Songs in the
+// trash count as missing.
+func insertTracks(ctx context.Context, tx *sqlx.Tx, albumID int, songIDs []int) error {
+	query := `INSERT INTO album_tracks(album_id, song_id, track_number)
+              SELECT $1, s.id, $3 FROM songs AS s WHERE s.id = $2 AND s.deleted_at IS NULL`
+
+	for i, songID := range songIDs {
+		res, err := tx.ExecContext(ctx, query, albumID, songID, i+1)
+		if err != nil {
+			return err
+		}
+
+		if added, err := res.RowsAffected(); err != nil || added == 0 {
+			if err != nil {
+				return err
+			}
+			return models.ErrSongNotFound
+		}
+	}
+
+	return nil
+}
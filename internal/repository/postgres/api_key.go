@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+type APIKeyRepository struct {
+	db *sqlx.DB
+}
+
+func NewAPIKeyRepository(db *sqlx.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+func (m *APIKeyRepository) AddAPIKey(ctx context.Context, key *models.APIKey) (int, error) {
+	var id int
+
+	query := `INSERT INTO api_keys(name, key_prefix, key_hash, scopes, created_by)
+              VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	err := m.db.QueryRowContext(ctx, query, key.Name, key.Prefix, key.KeyHash, key.Scopes, key.CreatedBy).Scan(&id)
+
+	return id, err
+}
+
+func (m *APIKeyRepository) GetAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	keys := make([]models.APIKey, 0)
+
+	query := `SELECT k.id, k.name, k.key_prefix, k.key_hash, k.scopes, k.created_by, k.created_at, k.revoked_at
+              FROM api_keys AS k
+              ORDER BY k.id`
+
+	err := m.db.SelectContext(ctx, &keys, query)
+	return keys, err
+}
+
+func (m *APIKeyRepository) GetAPIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	var key models.APIKey
+
+	query := `SELECT k.id, k.name, k.key_prefix, k.key_hash, k.scopes, k.created_by, k.created_at, k.revoked_at
+              FROM api_keys AS k
+              WHERE k.key_hash = $1`
+
+	err := m.db.GetContext(ctx, &key, query, hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func (m *APIKeyRepository) RevokeAPIKey(ctx context.Context, id int, now time.Time) error {
+	query := `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, $1) WHERE id = $2`
+	res, err := m.db.ExecContext(ctx, query, now, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return models.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
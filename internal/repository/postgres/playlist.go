@@ -0,0 +1,364 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+// countItemsQuery counts the items of a playlist. Items of songs in the trash
+// are kept for a restore but hidden, and positions count only the others.
+const countItemsQuery = `SELECT COUNT(pi.id)
+                         FROM playlist_items AS pi
+                         JOIN songs AS s ON s.id = pi.song_id
+                         WHERE pi.playlist_id = $1 AND s.deleted_at IS NULL`
+
+// playlistSlot is an item of a playlist in the order of positions.
+type playlistSlot struct {
+	ID      int  `db:"id"`
+	Visible bool `db:"visible"`
+}
+
+type PlaylistRepository struct {
+	db *sqlx.DB
+}
+
+func NewPlaylistRepository(db *sqlx.DB) *PlaylistRepository {
+	return &PlaylistRepository{db: db}
+}
+
+func (m *PlaylistRepository) Add(ctx context.Context, userId int, name string) (int, error) {
+	var id int
+
+	query := `INSERT INTO playlists(user_id, name) VALUES ($1, $2) RETURNING id`
+	err := m.db.QueryRowContext(ctx, query, userId, name).Scan(&id)
+	return id, err
+}
+
+func (m *PlaylistRepository) Delete(ctx context.Context, id int) error {
+	query := `DELETE 
+			  FROM playlists 
+			  WHERE id = $1`
+	_, err := m.db.ExecContext(ctx, query, id)
+	return err
+}
+
+func (m *PlaylistRepository) Edit(ctx context.Context, id int, input *models.EditPlaylistRequest) error {
+	if input.Name == nil {
+		return nil
+	}
+
+	query := `UPDATE playlists SET name = $1, updated_at = NOW() WHERE id = $2`
+	_, err := m.db.ExecContext(ctx, query, *input.Name, id)
+	if err != nil {
+		return fmt.Errorf("failed to execute update query for playlist with id - %d: %w", id, err)
+	}
+
+	return nil
+}
+
+func (m *PlaylistRepository) GetPlaylist(ctx context.Context, userId, id int) (*models.Playlist, error) {
+	var playlist models.Playlist
+
+	query := `SELECT p.id, p.user_id, p.name, p.created_at, p.updated_at
+              FROM playlists AS p
+              WHERE p.id = $1 AND p.user_id = $2`
+
+	err := m.db.GetContext(ctx, &playlist, query, id, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &playlist, nil
+}
+
+func (m *PlaylistRepository) GetPlaylists(ctx context.Context, userId, page, limit int) ([]models.Playlist, int, error) {
+	var (
+		playlists  []models.Playlist
+		totalCount int
+	)
+
+	query := `SELECT p.id, p.user_id, p.name, p.created_at, p.updated_at
+              FROM playlists AS p
+              WHERE p.user_id = $1
+              ORDER BY p.id LIMIT $2 OFFSET $3`
+
+	offset := (page - 1) * limit
+
+	err := m.db.SelectContext(ctx, &playlists, query, userId, limit, offset)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	err = m.db.GetContext(ctx, &totalCount, `SELECT COUNT(p.id) FROM playlists AS p WHERE p.user_id = $1`, userId)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	return playlists, totalCount, nil
+}
+
+func (m *PlaylistRepository) AddItem(ctx context.Context, playlistId, songId int) (int, error) {
+	var position int
+
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return position, err
+	}
+
+	// Locking the playlist serializes concurrent appends, which would
+	// otherwise pick the same position.
+	if err = lockPlaylist(ctx, tx, playlistId); err != nil {
+		_ = tx.Rollback()
+		return position, err
+	}
+
+	// Songs in the trash count as missing.
+	query := `INSERT INTO playlist_items(playlist_id, song_id, position)
+              SELECT $1, s.id, (SELECT COALESCE(MAX(pi.position), 0) + 1
+                                FROM playlist_items AS pi
+                                WHERE pi.playlist_id = $1)
+              FROM songs AS s
+              WHERE s.id = $2 AND s.deleted_at IS NULL`
+
+	res, err := tx.ExecContext(ctx, query, playlistId, songId)
+	if err != nil {
+		_ = tx.Rollback()
+		return position, err
+	}
+
+	if added, err := res.RowsAffected(); err != nil || added == 0 {
+		_ = tx.Rollback()
+		if err != nil {
+			return position, err
+		}
+		return position, models.ErrSongNotFound
+	}
+
+	err = tx.GetContext(ctx, &position, countItemsQuery, playlistId)
+	if err != nil {
+		_ = tx.Rollback()
+		return position, err
+	}
+
+	if err = touchPlaylist(ctx, tx, playlistId); err != nil {
+		_ = tx.Rollback()
+		return position, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return position, err
+	}
+
+	return position, nil
+}
+
+func (m *PlaylistRepository) RemoveItem(ctx context.Context, playlistId, position int) error {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM playlist_items
+              WHERE id = (SELECT pi.id
+                          FROM playlist_items AS pi
+                          JOIN songs AS s ON s.id = pi.song_id
+                          WHERE pi.playlist_id = $1 AND s.deleted_at IS NULL
+                          ORDER BY pi.position
+                          LIMIT 1 OFFSET $2)`
+
+	res, err := tx.ExecContext(ctx, query, playlistId, position-1)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if removed, err := res.RowsAffected(); err != nil || removed == 0 {
+		_ = tx.Rollback()
+		if err != nil {
+			return err
+		}
+		return models.ErrPlaylistItemNotFound
+	}
+
+	if err = touchPlaylist(ctx, tx, playlistId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (m *PlaylistRepository) MoveItem(ctx context.Context, playlistId, from, to int) error {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err = lockPlaylist(ctx, tx, playlistId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	var items []playlistSlot
+	query := `SELECT pi.id, s.deleted_at IS NULL AS visible
+              FROM playlist_items AS pi
+              JOIN songs AS s ON s.id = pi.song_id
+              WHERE pi.playlist_id = $1
+              ORDER BY pi.position`
+	if err = tx.SelectContext(ctx, &items, query, playlistId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	ids, ok := moveVisibleItem(items, from, to)
+	if !ok {
+		_ = tx.Rollback()
+		return models.ErrPlaylistItemNotFound
+	}
+
+	// Positions are flipped negative first so that renumbering never
+	// collides with the unique (playlist_id, position) constraint.
+	query = `UPDATE playlist_items SET position = -position WHERE playlist_id = $1`
+	if _, err = tx.ExecContext(ctx, query, playlistId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	query = `UPDATE playlist_items SET position = $1 WHERE id = $2`
+	for i, id := range ids {
+		if _, err = tx.ExecContext(ctx, query, i+1, id); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	if err = touchPlaylist(ctx, tx, playlistId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (m *PlaylistRepository) GetItems(ctx context.Context, playlistId int, page *models.Page) ([]models.PlaylistItem, int, error) {
+	var (
+		items      []models.PlaylistItem
+		totalCount int
+	)
+
+	query := `SELECT i.item_id, i.position, i.sort_key, s.id, s.group_id, g.name AS group_name,
+                     s.song_name, s.release_date, s.link, s.created_at, s.updated_at, s.version
+              FROM (SELECT pi.id AS item_id, pi.song_id, pi.position AS sort_key,
+                           ROW_NUMBER() OVER (ORDER BY pi.position) AS position
+                    FROM playlist_items AS pi
+                    JOIN songs AS s ON s.id = pi.song_id
+                    WHERE pi.playlist_id = $1 AND s.deleted_at IS NULL) AS i
+              JOIN songs AS s ON s.id = i.song_id
+              JOIN groups AS g ON g.id = s.group_id`
+
+	args := []interface{}{playlistId}
+	direction := "ASC"
+
+	if c := page.Cursor; c != nil {
+		op := ">"
+		if c.Backward {
+			op, direction = "<", "DESC"
+		}
+
+		args = append(args, c.Key)
+		query += fmt.Sprintf(" WHERE i.sort_key %s $%d::integer", op, len(args))
+	}
+
+	args = append(args, page.Limit)
+	query += fmt.Sprintf(" ORDER BY i.sort_key %s LIMIT $%d", direction, len(args))
+	if page.Cursor == nil {
+		args = append(args, page.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	err := m.db.SelectContext(ctx, &items, query, args...)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	if page.Cursor != nil && page.Cursor.Backward {
+		slices.Reverse(items)
+	}
+
+	err = m.db.GetContext(ctx, &totalCount, countItemsQuery, playlistId)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	return items, totalCount, nil
+}
+
+func (m *PlaylistRepository) PlaylistExists(ctx context.Context, userId, id int) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT id 
+    				 		FROM playlists 
+    				 		WHERE id = $1 AND user_id = $2)`
+	err := m.db.QueryRowContext(ctx, query, id, userId).Scan(&exists)
+	return exists, err
+}
+
+// moveVisibleItem moves the item at position from to position to, both
+// counting visible items only, and returns the ids of all items in their new
+// order. Hidden items keep their places.
+func moveVisibleItem(items []playlistSlot, from, to int) ([]int, bool) {
+	var visible, slots []int
+	for i, item := range items {
+		if item.Visible {
+			visible = append(visible, item.ID)
+			slots = append(slots, i)
+		}
+	}
+
+	if from < 1 || from > len(visible) || to < 1 || to > len(visible) {
+		return nil, false
+	}
+
+	moved := visible[from-1]
+	visible = slices.Insert(slices.Delete(visible, from-1, from), to-1, moved)
+
+	ids := make([]int, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	for i, slot := range slots {
+		ids[slot] = visible[i]
+	}
+
+	return ids, true
+}
+
+func lockPlaylist(ctx context.Context, tx *sqlx.Tx, id int) error {
+	var locked int
+	return tx.GetContext(ctx, &locked, `SELECT id FROM playlists WHERE id = $1 FOR UPDATE`, id)
+}
+
+func touchPlaylist(ctx context.Context, tx *sqlx.Tx, id int) error {
+	_, err := tx.ExecContext(ctx, `UPDATE playlists SET updated_at = NOW() WHERE id = $1`, id)
+	return err
+}
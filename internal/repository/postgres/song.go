@@ -2,11 +2,15 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
 	"github.com/LionJr/music-library/internal/models"
 	"github.com/jmoiron/sqlx"
-	"strings"
 )
 
 type SongRepository struct {
@@ -18,60 +22,138 @@ func NewSongRepository(db *sqlx.DB) *SongRepository {
 }
 
 func (m *SongRepository) Add(ctx context.Context, song *models.Song) (int, error) {
-	var (
-		id     int
-		exists bool
-	)
+	var id int
 
-	query := `SELECT EXISTS(SELECT id FROM songs WHERE group_name = $1 AND song_name = $2)`
-	err := m.db.QueryRowContext(ctx, query, song.GroupName, song.SongName).Scan(&exists)
+	tx, err := m.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return id, err
 	}
 
-	if exists {
-		return id, errors.New("song already exists")
+	if id, err = insertSong(ctx, tx, song); err != nil {
+		_ = tx.Rollback()
+		return id, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return id, err
+	}
+
+	return id, nil
+}
+
+func (m *SongRepository) ImportSongs(ctx context.Context, songs []models.Song) ([]int, error) {
+	ids := make([]int, len(songs))
+
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range songs {
+		if ids[i], err = insertSong(ctx, tx, &songs[i]); err != nil {
+			if errors.Is(err, models.ErrSongExists) {
+				continue
+			}
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// insertSong adds the song, its group if needed and its verses. It returns
+// models.ErrSongExists when the group already has a song of that name; the
+// transaction stays usable then.
+func insertSong(ctx context.Context, tx *sqlx.Tx, song *models.Song) (int, error) {
+	var id int
+
+	groupID, err := resolveGroup(ctx, tx, song.GroupName)
+	if err != nil {
+		return id, err
 	}
 
-	query = `INSERT INTO songs(group_name, song_name, release_date, link) VALUES ($1, $2, $3, $4) RETURNING id`
-	tx, _ := m.db.Begin()
-	if err := tx.QueryRowContext(ctx, query,
-		song.GroupName,
+	query := `INSERT INTO songs(group_id, song_name, release_date, link) VALUES ($1, $2, $3, $4)
+              ON CONFLICT (group_id, song_name) WHERE deleted_at IS NULL DO NOTHING
+              RETURNING id`
+	err = tx.QueryRowContext(ctx, query,
+		groupID,
 		song.SongName,
 		song.ReleaseDate,
 		song.Link,
-	).Scan(&id); err != nil {
-		_ = tx.Rollback()
+	).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return id, models.ErrSongExists
+	}
+	if err != nil {
 		return id, err
 	}
 
 	verseQuery := `INSERT INTO song_verses(song_id, verse_index, text) VALUES ($1, $2, $3)`
 
-	verses := strings.Split(song.Text, "\n\n")
+	verses := models.SplitVerses(song.Text)
 	for index := range verses {
-		if _, err := tx.ExecContext(ctx, verseQuery, id, index+1, verses[index]); err != nil {
-			_ = tx.Rollback()
+		if _, err = tx.ExecContext(ctx, verseQuery, id, index+1, verses[index]); err != nil {
 			return id, err
 		}
 	}
 
-	if err = tx.Commit(); err != nil {
-		_ = tx.Rollback()
+	if _, err = recordRevision(ctx, tx, id, models.RevisionCreate, nil); err != nil {
 		return id, err
 	}
 
 	return id, nil
 }
 
+// Delete moves the song to the trash. Its verses, album tracks and playlist
+// items are kept for a restore; reads of albums and playlists skip them.
 func (m *SongRepository) Delete(ctx context.Context, id int) error {
-	query := `DELETE 
-			  FROM songs 
-			  WHERE id = $1`
-	_, err := m.db.ExecContext(ctx, query, id)
-	return err
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, `UPDATE songs SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`, id)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	// A song that was already in the trash has nothing new to record.
+	if deleted > 0 {
+		before, err := songSnapshot(ctx, tx, id)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+
+		if _, err = addRevision(ctx, tx, id, models.RevisionDelete, before, nil); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return nil
 }
 
-func (m *SongRepository) Edit(ctx context.Context, id int, input *models.EditSongRequest) error {
+func (m *SongRepository) Edit(ctx context.Context, id int, input *models.EditSongRequest, version int) (int, error) {
 	var (
 		conditions      []string
 		verseConditions []string
@@ -79,9 +161,57 @@ func (m *SongRepository) Edit(ctx context.Context, id int, input *models.EditSon
 		verseArgs       []interface{}
 	)
 
+	if input.Verse != nil {
+		verseExists, err := m.VerseExists(ctx, id, input.Verse.Index)
+		if err != nil {
+			return 0, err
+		}
+
+		if !verseExists {
+			return 0, models.ErrVerseNotFound
+		}
+	}
+
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = lockSongVerses(ctx, tx, id); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	current, err := songVersion(ctx, tx, id)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+	if version != 0 && version != current {
+		_ = tx.Rollback()
+		return 0, models.ErrVersionMismatch
+	}
+
+	if input.GroupName == nil && input.SongName == nil && input.ReleaseDate == nil && input.Link == nil && input.Verse == nil {
+		_ = tx.Rollback()
+		return current, nil
+	}
+
+	before, err := songSnapshot(ctx, tx, id)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
 	if input.GroupName != nil {
-		args = append(args, *input.GroupName)
-		conditions = append(conditions, fmt.Sprintf("group_name = $%d", len(args)))
+		groupID, err := resolveGroup(ctx, tx, *input.GroupName)
+		if err != nil {
+			_ = tx.Rollback()
+			return 0, err
+		}
+
+		args = append(args, groupID)
+		conditions = append(conditions, fmt.Sprintf("group_id = $%d", len(args)))
 	}
 
 	if input.SongName != nil {
@@ -100,15 +230,6 @@ func (m *SongRepository) Edit(ctx context.Context, id int, input *models.EditSon
 	}
 
 	if input.Verse != nil {
-		verseExists, err := m.VerseExists(ctx, id, input.Verse.Index)
-		if err != nil {
-			return err
-		}
-
-		if !verseExists {
-			return errors.New("no verse found with provided index")
-		}
-
 		verseArgs = append(verseArgs, input.Verse.Text)
 		verseConditions = append(verseConditions, fmt.Sprintf("text = $%d", len(verseArgs)))
 	}
@@ -117,9 +238,14 @@ func (m *SongRepository) Edit(ctx context.Context, id int, input *models.EditSon
 		args = append(args, id)
 		query := `UPDATE songs SET` + " " + strings.Join(conditions, ", ") + fmt.Sprintf(" WHERE id = $%d", len(args))
 
-		_, err := m.db.ExecContext(ctx, query, args...)
+		_, err = tx.ExecContext(ctx, query, args...)
+		if isPgError(err, uniqueViolation) {
+			_ = tx.Rollback()
+			return 0, models.ErrSongExists
+		}
 		if err != nil {
-			return fmt.Errorf("failed to execute update query for song with id - %d: %w", id, err)
+			_ = tx.Rollback()
+			return 0, fmt.Errorf("failed to execute update query for song with id - %d: %w", id, err)
 		}
 	}
 
@@ -127,56 +253,142 @@ func (m *SongRepository) Edit(ctx context.Context, id int, input *models.EditSon
 		verseArgs = append(verseArgs, id, input.Verse.Index)
 		verseQuery := `UPDATE song_verses SET` + " " + strings.Join(verseConditions, ", ") + fmt.Sprintf(" WHERE song_id = $%d AND verse_index = $%d", len(verseArgs)-1, len(verseArgs))
 
-		_, err := m.db.ExecContext(ctx, verseQuery, verseArgs...)
+		_, err = tx.ExecContext(ctx, verseQuery, verseArgs...)
 		if err != nil {
-			return fmt.Errorf("failed to execute verse update query for song with id - %d: %w", id, err)
+			_ = tx.Rollback()
+			return 0, fmt.Errorf("failed to execute verse update query for song with id - %d: %w", id, err)
 		}
 	}
 
-	return nil
+	// An edit of a verse alone leaves the song row as it is.
+	if len(args) == 0 {
+		if err = touchSong(ctx, tx, id); err != nil {
+			_ = tx.Rollback()
+			return 0, err
+		}
+	}
+
+	if _, err = recordRevision(ctx, tx, id, models.RevisionEdit, before); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	current, err = songVersion(ctx, tx, id)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	return current, nil
+}
+
+// songVersion returns the version of a song, kept up by the songs_touch
+// trigger.
+func songVersion(ctx context.Context, tx *sqlx.Tx, id int) (int, error) {
+	var version int
+	err := tx.QueryRowContext(ctx, `SELECT version FROM songs WHERE id = $1`, id).Scan(&version)
+	return version, err
+}
+
+// releaseDateKey is the release date as a sortable string, models.Date
+// without a date being the empty string, so that keyset cursors can carry it.
+const releaseDateKey = `COALESCE(to_char(s.release_date, 'YYYY-MM-DD'), '')`
+
+var songSortExpressions = map[string]string{
+	"id":           "s.id",
+	"group_name":   "g.name",
+	"song_name":    "s.song_name",
+	"release_date": releaseDateKey,
+	"link":         "s.link",
+	"created_at":   "s.created_at",
+	"updated_at":   "s.updated_at",
+	"track_number": "t.track_number",
+}
+
+func (m *SongRepository) GetSong(ctx context.Context, id int) (*models.Song, error) {
+	var song models.Song
+
+	query := `SELECT s.id, s.group_id, g.name AS group_name, s.song_name,
+                     s.release_date, s.link, s.created_at, s.updated_at, s.version
+              FROM songs AS s JOIN groups AS g ON g.id = s.group_id
+              WHERE s.id = $1 AND s.deleted_at IS NULL`
+	err := m.db.GetContext(ctx, &song, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrSongNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var verses []models.Verse
+	verseQuery := `SELECT sv.id, sv.song_id, sv.verse_index, sv.text
+                   FROM song_verses AS sv
+                   WHERE sv.song_id = $1
+                   ORDER BY sv.verse_index`
+	if err = m.db.SelectContext(ctx, &verses, verseQuery, id); err != nil {
+		return nil, err
+	}
+	song.Text = models.JoinVerses(verses)
+
+	return &song, nil
 }
 
-func (m *SongRepository) GetSongs(ctx context.Context, group, song string, page, limit int) ([]models.Song, int, error) {
+func (m *SongRepository) GetSongs(ctx context.Context, filter *models.SongFilter, page *models.Page) ([]models.Song, int, error) {
 	var (
 		songs      []models.Song
-		conditions []string
-		args       []interface{}
 		totalCount int
 	)
 
-	query := `SELECT s.id, s.group_name, s.song_name, 
-                     s.release_date, s.link, s.created_at, s.updated_at 
-			  FROM songs AS s`
+	conditions, args := songConditions(filter)
 
-	if group != "" {
-		args = append(args, group)
-		conditions = append(conditions, fmt.Sprintf("s.group_name = $%d", len(args)))
+	backward := page.Cursor != nil && page.Cursor.Backward
+	orderBy, err := songOrderBy(filter.SortBy, filter.SortDesc != backward)
+	if err != nil {
+		return nil, totalCount, err
 	}
 
-	if song != "" {
-		args = append(args, song)
-		conditions = append(conditions, fmt.Sprintf("s.song_name = $%d", len(args)))
+	pageConditions, pageArgs := conditions, args
+	if page.Cursor != nil {
+		var keyset string
+		keyset, pageArgs = songKeyset(filter, page.Cursor, args)
+		pageConditions = append(conditions[:len(conditions):len(conditions)], keyset)
 	}
 
-	if len(conditions) > 0 {
-		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	from, trackNumber := songSource(filter)
+	query := `SELECT s.id, s.group_id, g.name AS group_name, s.song_name, 
+                     s.release_date, s.link, s.created_at, s.updated_at, s.version, ` + trackNumber + ` AS track_number` + from
+
+	if len(pageConditions) > 0 {
+		query += ` WHERE ` + strings.Join(pageConditions, " AND ")
 	}
 
-	offset := (page - 1) * limit
-	args = append(args, limit, offset)
-	query += fmt.Sprintf(" ORDER BY s.id LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	pageArgs = append(pageArgs, page.Limit)
+	query += fmt.Sprintf(" ORDER BY %s LIMIT $%d", orderBy, len(pageArgs))
+	if page.Cursor == nil {
+		pageArgs = append(pageArgs, page.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(pageArgs))
+	}
 
-	err := m.db.SelectContext(ctx, &songs, query, args...)
+	err = m.db.SelectContext(ctx, &songs, query, pageArgs...)
 	if err != nil {
 		return nil, totalCount, err
 	}
 
-	countQuery := `SELECT COUNT(songs.id) FROM songs`
+	if backward {
+		slices.Reverse(songs)
+	}
+
+	countQuery := `SELECT COUNT(s.id)` + from
 	if len(conditions) > 0 {
-		countQuery += ` AND ` + strings.Join(conditions, ` AND `)
+		countQuery += ` WHERE ` + strings.Join(conditions, ` AND `)
 	}
 
-	err = m.db.GetContext(ctx, &totalCount, countQuery, args[:len(conditions)]...)
+	err = m.db.GetContext(ctx, &totalCount, countQuery, args...)
 	if err != nil {
 		return nil, totalCount, err
 	}
@@ -184,7 +396,112 @@ func (m *SongRepository) GetSongs(ctx context.Context, group, song string, page,
 	return songs, totalCount, nil
 }
 
-func (m *SongRepository) GetSongVerses(ctx context.Context, songId, page, limit int) ([]models.Verse, int, error) {
+// exportFetchSize rows are fetched from the export cursor at a time.
+const exportFetchSize = 500
+
+// exportRow is a song joined with one of its verses; songs without verses
+// come as a single row without one.
+type exportRow struct {
+	models.Song
+	VerseID    sql.NullInt64  `db:"verse_id"`
+	VerseIndex sql.NullInt64  `db:"verse_index"`
+	VerseText  sql.NullString `db:"verse_text"`
+}
+
+func (m *SongRepository) ExportSongs(ctx context.Context, filter *models.SongFilter, emit func(*models.SongExport) error) error {
+	conditions, args := songConditions(filter)
+
+	orderBy, err := songOrderBy(filter.SortBy, filter.SortDesc)
+	if err != nil {
+		return err
+	}
+
+	from, trackNumber := songSource(filter)
+	query := `SELECT s.id, s.group_id, g.name AS group_name, s.song_name,
+                     s.release_date, s.link, s.created_at, s.updated_at, s.version, ` + trackNumber + ` AS track_number,
+                     v.id AS verse_id, v.verse_index, v.text AS verse_text` + from + `
+              LEFT JOIN song_verses AS v ON v.song_id = s.id`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY ` + orderBy + `, v.verse_index`
+
+	// The cursor lives until the transaction ends, so the result set is
+	// read from the server in pieces instead of all at once.
+	tx, err := m.db.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err = tx.ExecContext(ctx, `DECLARE song_export NO SCROLL CURSOR FOR `+query, args...); err != nil {
+		return err
+	}
+
+	var current *models.SongExport
+	flush := func() error {
+		if current == nil {
+			return nil
+		}
+		current.Text = models.JoinVerses(current.Verses)
+		song := current
+		current = nil
+		return emit(song)
+	}
+
+	fetch := fmt.Sprintf(`FETCH %d FROM song_export`, exportFetchSize)
+	for {
+		rows, err := tx.QueryxContext(ctx, fetch)
+		if err != nil {
+			return err
+		}
+
+		fetched := 0
+		for rows.Next() {
+			fetched++
+
+			var row exportRow
+			if err = rows.StructScan(&row); err != nil {
+				_ = rows.Close()
+				return err
+			}
+
+			if current == nil || current.ID != row.ID {
+				if err = flush(); err != nil {
+					_ = rows.Close()
+					return err
+				}
+				current = &models.SongExport{Song: row.Song, Verses: []models.Verse{}}
+			}
+
+			if row.VerseID.Valid {
+				current.Verses = append(current.Verses, models.Verse{
+					Id:     int(row.VerseID.Int64),
+					SongId: row.ID,
+					Index:  int(row.VerseIndex.Int64),
+					Text:   row.VerseText.String,
+				})
+			}
+		}
+		if err = rows.Err(); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		_ = rows.Close()
+
+		if fetched < exportFetchSize {
+			break
+		}
+	}
+
+	if err = flush(); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m *SongRepository) GetSongVerses(ctx context.Context, songId int, page *models.Page) ([]models.Verse, int, error) {
 	var (
 		verses     []models.Verse
 		totalCount int
@@ -192,17 +509,47 @@ func (m *SongRepository) GetSongVerses(ctx context.Context, songId, page, limit
 
 	query := `SELECT sv.id, sv.song_id, sv.verse_index, sv.text 
               FROM song_verses AS sv
-              WHERE sv.song_id = $1
-              ORDER BY sv.verse_index LIMIT $2 OFFSET $3`
+              JOIN songs AS s ON s.id = sv.song_id AND s.deleted_at IS NULL
+              WHERE sv.song_id = $1`
 
-	offset := (page - 1) * limit
+	args := []interface{}{songId}
+	direction := "ASC"
+
+	if c := page.Cursor; c != nil {
+		index, err := strconv.Atoi(c.Key)
+		if err != nil {
+			return nil, totalCount, fmt.Errorf("invalid verse cursor key %q: %w", c.Key, err)
+		}
+
+		op := ">"
+		if c.Backward {
+			op, direction = "<", "DESC"
+		}
+
+		args = append(args, index, c.ID)
+		query += fmt.Sprintf(" AND (sv.verse_index, sv.id) %s ($%d, $%d)", op, len(args)-1, len(args))
+	}
+
+	args = append(args, page.Limit)
+	query += fmt.Sprintf(" ORDER BY sv.verse_index %s, sv.id %s LIMIT $%d", direction, direction, len(args))
+	if page.Cursor == nil {
+		args = append(args, page.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
 
-	err := m.db.SelectContext(ctx, &verses, query, songId, limit, offset)
+	err := m.db.SelectContext(ctx, &verses, query, args...)
 	if err != nil {
 		return nil, totalCount, err
 	}
 
-	countQuery := `SELECT COUNT(sv.id) FROM song_verses AS sv WHERE sv.song_id = $1`
+	if page.Cursor != nil && page.Cursor.Backward {
+		slices.Reverse(verses)
+	}
+
+	countQuery := `SELECT COUNT(sv.id)
+                   FROM song_verses AS sv
+                   JOIN songs AS s ON s.id = sv.song_id AND s.deleted_at IS NULL
+                   WHERE sv.song_id = $1`
 
 	err = m.db.GetContext(ctx, &totalCount, countQuery, songId)
 	if err != nil {
@@ -212,11 +559,48 @@ func (m *SongRepository) GetSongVerses(ctx context.Context, songId, page, limit
 	return verses, totalCount, nil
 }
 
+func (m *SongRepository) SearchVerses(ctx context.Context, text string, page, limit int) ([]models.VerseSearchResult, int, error) {
+	var (
+		results    []models.VerseSearchResult
+		totalCount int
+	)
+
+	query := `SELECT sv.song_id, g.name AS group_name, s.song_name, sv.verse_index,
+                     ts_headline('simple', sv.text, q, 'StartSel=<b>, StopSel=</b>') AS snippet,
+                     ts_rank(sv.text_search, q) AS rank
+              FROM song_verses AS sv
+              JOIN songs AS s ON s.id = sv.song_id AND s.deleted_at IS NULL
+              JOIN groups AS g ON g.id = s.group_id,
+                   plainto_tsquery('simple', $1) AS q
+              WHERE sv.text_search @@ q
+              ORDER BY rank DESC, sv.song_id, sv.verse_index
+              LIMIT $2 OFFSET $3`
+
+	offset := (page - 1) * limit
+
+	err := m.db.SelectContext(ctx, &results, query, text, limit, offset)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	countQuery := `SELECT COUNT(sv.id)
+                   FROM song_verses AS sv
+                   JOIN songs AS s ON s.id = sv.song_id AND s.deleted_at IS NULL
+                   WHERE sv.text_search @@ plainto_tsquery('simple', $1)`
+
+	err = m.db.GetContext(ctx, &totalCount, countQuery, text)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	return results, totalCount, nil
+}
+
 func (m *SongRepository) SongExists(ctx context.Context, id int) (bool, error) {
 	var exists bool
 	query := `SELECT EXISTS(SELECT id 
     				 		FROM songs 
-    				 		WHERE id = $1)`
+    				 		WHERE id = $1 AND deleted_at IS NULL)`
 	err := m.db.QueryRowContext(ctx, query, id).Scan(&exists)
 	return exists, err
 }
@@ -225,7 +609,144 @@ func (m *SongRepository) VerseExists(ctx context.Context, songId, index int) (bo
 	var exists bool
 	query := `SELECT EXISTS(SELECT sv.id 
     				 		FROM song_verses AS sv
+    				 		JOIN songs AS s ON s.id = sv.song_id AND s.deleted_at IS NULL
     				 		WHERE sv.song_id = $1 AND sv.verse_index = $2)`
 	err := m.db.QueryRowContext(ctx, query, songId, index).Scan(&exists)
 	return exists, err
 }
+
+func songConditions(filter *models.SongFilter) ([]string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	// Songs in the trash are left out of every listing.
+	conditions = append(conditions, "s.deleted_at IS NULL")
+
+	if filter.AlbumID != 0 {
+		args = append(args, filter.AlbumID)
+		conditions = append(conditions, fmt.Sprintf("t.album_id = $%d", len(args)))
+	}
+
+	if filter.Group != "" {
+		args = append(args, escapeLike(filter.Group))
+		conditions = append(conditions, fmt.Sprintf("g.name ILIKE '%%' || $%d || '%%'", len(args)))
+	}
+
+	if filter.Song != "" {
+		args = append(args, escapeLike(filter.Song))
+		conditions = append(conditions, fmt.Sprintf("s.song_name ILIKE '%%' || $%d || '%%'", len(args)))
+	}
+
+	if filter.ReleasedAfter != nil {
+		args = append(args, filter.ReleasedAfter.Format(models.DateLayout))
+		conditions = append(conditions, fmt.Sprintf("s.release_date >= $%d", len(args)))
+	}
+
+	if filter.ReleasedBefore != nil {
+		args = append(args, filter.ReleasedBefore.Format(models.DateLayout))
+		conditions = append(conditions, fmt.Sprintf("s.release_date <= $%d", len(args)))
+	}
+
+	if filter.CreatedAfter != nil {
+		args = append(args, filter.CreatedAfter.UTC())
+		conditions = append(conditions, fmt.Sprintf("s.created_at >= $%d", len(args)))
+	}
+
+	if filter.CreatedBefore != nil {
+		args = append(args, filter.CreatedBefore.UTC())
+		conditions = append(conditions, fmt.Sprintf("s.created_at <= $%d", len(args)))
+	}
+
+	return conditions, args
+}
+
+func songOrderBy(sortBy string, desc bool) (string, error) {
+	if sortBy == "" {
+		sortBy = "id"
+	}
+
+	expr, ok := songSortExpressions[sortBy]
+	if !ok {
+		return "", fmt.Errorf("unknown sort column %q", sortBy)
+	}
+
+	direction := "ASC"
+	if desc {
+		direction = "DESC"
+	}
+
+	return fmt.Sprintf("%s %s, s.id %s", expr, direction, direction), nil
+}
+
+// songKeyset builds the predicate selecting rows past the cursor in the
+// direction it points to, comparing (sort key, id) row values.
+func songKeyset(filter *models.SongFilter, c *models.Cursor, args []interface{}) (string, []interface{}) {
+	op := ">"
+	if filter.SortDesc != c.Backward {
+		op = "<"
+	}
+
+	args = args[:len(args):len(args)]
+
+	switch filter.SortBy {
+	case "", "id":
+		args = append(args, c.ID)
+		return fmt.Sprintf("s.id %s $%d", op, len(args)), args
+	case "created_at", "updated_at":
+		args = append(args, c.Key, c.ID)
+		return fmt.Sprintf("(%s, s.id) %s ($%d::timestamp, $%d)",
+			songSortExpressions[filter.SortBy], op, len(args)-1, len(args)), args
+	case "track_number":
+		args = append(args, c.Key, c.ID)
+		return fmt.Sprintf("(t.track_number, s.id) %s ($%d::integer, $%d)", op, len(args)-1, len(args)), args
+	default:
+		args = append(args, c.Key, c.ID)
+		return fmt.Sprintf("(%s, s.id) %s ($%d, $%d)",
+			songSortExpressions[filter.SortBy], op, len(args)-1, len(args)), args
+	}
+}
+
+// songSource returns the FROM clause of GetSongs and the expression selected
+// as the track number; album tracks are only joined when listing an album.
+func songSource(filter *models.SongFilter) (string, string) {
+	from := ` FROM songs AS s JOIN groups AS g ON g.id = s.group_id`
+	if filter.AlbumID == 0 {
+		return from, "NULL::integer"
+	}
+	return from + ` JOIN album_tracks AS t ON t.song_id = s.id`, "t.track_number"
+}
+
+// escapeLike escapes the LIKE wildcards in s so it is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// resolveGroup returns the id of the group with the given name, creating the
+// group if it does not exist yet.
+func resolveGroup(ctx context.Context, q sqlx.QueryerContext, name string) (int, error) {
+	var id int
+
+	query := `WITH inserted AS (
+                  INSERT INTO groups (name) VALUES ($1)
+                  ON CONFLICT (name) DO NOTHING
+                  RETURNING id
+              )
+              SELECT id FROM inserted
+              UNION ALL
+              SELECT id FROM groups WHERE name = $1
+              LIMIT 1`
+
+	err := q.QueryRowxContext(ctx, query, name).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		// A concurrent transaction created the group after this statement's
+		// snapshot was taken; it is visible to a fresh one.
+		err = q.QueryRowxContext(ctx, `SELECT id FROM groups WHERE name = $1`, name).Scan(&id)
+	}
+	if err != nil {
+		return id, fmt.Errorf("resolve group %q: %w", name, err)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,282 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+func (m *SongRepository) AddVerse(ctx context.Context, songId, position int, text string) (int, error) {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := lockSongVerses(ctx, tx, songId)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	before, err := songSnapshot(ctx, tx, songId)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	if position == 0 {
+		position = count + 1
+	}
+	if position < 1 || position > count+1 {
+		_ = tx.Rollback()
+		return 0, models.ErrVerseNotFound
+	}
+
+	if err = shiftVerses(ctx, tx, songId, position, 1); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	query := `INSERT INTO song_verses(song_id, verse_index, text) VALUES ($1, $2, $3)`
+	if _, err = tx.ExecContext(ctx, query, songId, position, text); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	if err = touchSong(ctx, tx, songId); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	if _, err = recordRevision(ctx, tx, songId, models.RevisionAddVerse, before); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	return position, nil
+}
+
+func (m *SongRepository) DeleteVerse(ctx context.Context, songId, index int) error {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err = lockSongVerses(ctx, tx, songId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	before, err := songSnapshot(ctx, tx, songId)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	query := `DELETE FROM song_verses WHERE song_id = $1 AND verse_index = $2`
+	res, err := tx.ExecContext(ctx, query, songId, index)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if affected == 0 {
+		_ = tx.Rollback()
+		return models.ErrVerseNotFound
+	}
+
+	if err = shiftVerses(ctx, tx, songId, index+1, -1); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err = touchSong(ctx, tx, songId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if _, err = recordRevision(ctx, tx, songId, models.RevisionDeleteVerse, before); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (m *SongRepository) ReorderVerses(ctx context.Context, songId int, order []int) error {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err = lockSongVerses(ctx, tx, songId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	before, err := songSnapshot(ctx, tx, songId)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	var ids []int
+	query := `SELECT sv.id FROM song_verses AS sv WHERE sv.song_id = $1 ORDER BY sv.verse_index`
+	if err = tx.SelectContext(ctx, &ids, query, songId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if !isPermutation(order, len(ids)) {
+		_ = tx.Rollback()
+		return models.ErrInvalidVerseOrder
+	}
+
+	// Indices are flipped negative first so that renumbering never collides
+	// with the unique (song_id, verse_index) constraint.
+	query = `UPDATE song_verses SET verse_index = -verse_index WHERE song_id = $1`
+	if _, err = tx.ExecContext(ctx, query, songId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	query = `UPDATE song_verses SET verse_index = $1 WHERE id = $2`
+	for i, index := range order {
+		if _, err = tx.ExecContext(ctx, query, i+1, ids[index-1]); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	if err = touchSong(ctx, tx, songId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if _, err = recordRevision(ctx, tx, songId, models.RevisionReorderVerses, before); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (m *SongRepository) ReplaceText(ctx context.Context, songId int, text string) (int, error) {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = lockSongVerses(ctx, tx, songId); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	before, err := songSnapshot(ctx, tx, songId)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	verses := models.SplitVerses(text)
+	if err = replaceVerses(ctx, tx, songId, verses); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	if err = touchSong(ctx, tx, songId); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	if _, err = recordRevision(ctx, tx, songId, models.RevisionReplaceText, before); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	return len(verses), nil
+}
+
+// lockSongVerses locks the song so that concurrent verse changes are applied
+// one after another, and returns its verse count.
+func lockSongVerses(ctx context.Context, tx *sqlx.Tx, songId int) (int, error) {
+	var id int
+	err := tx.QueryRowContext(ctx, `SELECT id FROM songs WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, songId).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, models.ErrSongNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	query := `SELECT COUNT(sv.id) FROM song_verses AS sv WHERE sv.song_id = $1`
+	err = tx.QueryRowContext(ctx, query, songId).Scan(&count)
+
+	return count, err
+}
+
+// touchSong updates the song row after a change of its verses, so that the
+// songs_touch trigger bumps its updated_at and version.
+func touchSong(ctx context.Context, tx *sqlx.Tx, songId int) error {
+	_, err := tx.ExecContext(ctx, `UPDATE songs SET updated_at = NOW() WHERE id = $1`, songId)
+	return err
+}
+
+// shiftVerses moves the verses from index on by delta. Like ReorderVerses it
+// goes through negative indices to stay clear of the unique constraint.
+func shiftVerses(ctx context.Context, tx *sqlx.Tx, songId, from, delta int) error {
+	query := `UPDATE song_verses SET verse_index = -(verse_index + $1) WHERE song_id = $2 AND verse_index >= $3`
+	if _, err := tx.ExecContext(ctx, query, delta, songId, from); err != nil {
+		return err
+	}
+
+	query = `UPDATE song_verses SET verse_index = -verse_index WHERE song_id = $1 AND verse_index < 0`
+	_, err := tx.ExecContext(ctx, query, songId)
+	return err
+}
+
+// isPermutation reports whether order lists each of 1..n exactly once.
+func isPermutation(order []int, n int) bool {
+	if len(order) != n {
+		return false
+	}
+
+	sorted := slices.Sorted(slices.Values(order))
+	for i, index := range sorted {
+		if index != i+1 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,337 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+// RunAlbumRepo runs the album.Repo contract, including listing songs by
+// album through song.Repo.
+func RunAlbumRepo(t *testing.T, newRepos ReposFactory) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repos *Repos)
+	}{
+		{"AddAndGet", testAlbumAddAndGet},
+		{"AddErrors", testAlbumAddErrors},
+		{"Edit", testAlbumEdit},
+		{"GetAlbums", testGetAlbums},
+		{"GetSongsByAlbum", testGetSongsByAlbum},
+		{"DeleteSong", testAlbumDeleteSong},
+		{"Delete", testAlbumDelete},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepos(t))
+		})
+	}
+}
+
+// mustAddAlbum adds an album of group with songIDs as its tracks.
+func mustAddAlbum(t *testing.T, repos *Repos, groupID int, title string, songIDs ...int) int {
+	t.Helper()
+
+	id, err := repos.Albums.Add(context.Background(), &models.NewAlbumRequest{
+		GroupID:     groupID,
+		Title:       title,
+		ReleaseDate: "16.07.2006",
+		CoverLink:   "https://example.com/" + title + ".jpg",
+		Tracks:      songIDs,
+	})
+	if err != nil {
+		t.Fatalf("Add(%q): %v", title, err)
+	}
+	return id
+}
+
+func mustGetAlbum(t *testing.T, repos *Repos, id int) *models.Album {
+	t.Helper()
+
+	a, err := repos.Albums.GetAlbum(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetAlbum(%d): %v", id, err)
+	}
+	return a
+}
+
+// trackSongs returns the song ids of the album's tracks, checking that they
+// are ordered by number.
+func trackSongs(t *testing.T, a *models.Album) []int {
+	t.Helper()
+
+	ids := make([]int, 0, len(a.Tracks))
+	for i, track := range a.Tracks {
+		if i > 0 && track.Number <= a.Tracks[i-1].Number {
+			t.Fatalf("tracks out of order: %+v", a.Tracks)
+		}
+		ids = append(ids, track.SongID)
+	}
+	return ids
+}
+
+// addMuse adds three songs of one group and returns the group id and the
+// song ids.
+func addMuse(t *testing.T, repos *Repos) (int, []int) {
+	t.Helper()
+
+	ids := []int{
+		mustAdd(t, repos.Songs, newSong("Muse", "Hysteria", "one")),
+		mustAdd(t, repos.Songs, newSong("Muse", "Time Is Running Out", "two")),
+		mustAdd(t, repos.Songs, newSong("Muse", "Stockholm Syndrome", "three")),
+	}
+
+	got, _ := mustGetSongs(t, repos.Songs, &models.SongFilter{}, 1, 1)
+	return got[0].GroupID, ids
+}
+
+func testAlbumAddAndGet(t *testing.T, repos *Repos) {
+	groupID, songs := addMuse(t, repos)
+
+	id := mustAddAlbum(t, repos, groupID, "Absolution", songs[2], songs[0])
+
+	a := mustGetAlbum(t, repos, id)
+	if a == nil {
+		t.Fatalf("GetAlbum(%d) = nil", id)
+	}
+	if a.Title != "Absolution" || a.GroupID != groupID || a.GroupName != "Muse" ||
+		a.ReleaseDate != "16.07.2006" || a.CoverLink != "https://example.com/Absolution.jpg" {
+		t.Errorf("GetAlbum(%d) = %+v", id, a)
+	}
+	if len(a.Tracks) != 2 || a.Tracks[0] != (models.Track{Number: 1, SongID: songs[2], SongName: "Stockholm Syndrome"}) ||
+		a.Tracks[1] != (models.Track{Number: 2, SongID: songs[0], SongName: "Hysteria"}) {
+		t.Errorf("tracks = %+v", a.Tracks)
+	}
+
+	if a := mustGetAlbum(t, repos, id+100); a != nil {
+		t.Fatalf("GetAlbum(missing) = %+v, want nil", a)
+	}
+}
+
+func testAlbumAddErrors(t *testing.T, repos *Repos) {
+	ctx := context.Background()
+	groupID, songs := addMuse(t, repos)
+	mustAddAlbum(t, repos, groupID, "Absolution", songs[0])
+
+	tests := []struct {
+		name  string
+		input models.NewAlbumRequest
+		want  error
+	}{
+		{"duplicate title", models.NewAlbumRequest{GroupID: groupID, Title: "Absolution"}, models.ErrAlbumExists},
+		{"missing group", models.NewAlbumRequest{GroupID: groupID + 100, Title: "Drones"}, models.ErrGroupNotFound},
+		{"missing song", models.NewAlbumRequest{GroupID: groupID, Title: "Drones", Tracks: []int{songs[1], songs[2] + 100}}, models.ErrSongNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := repos.Albums.Add(ctx, &tt.input); !errors.Is(err, tt.want) {
+				t.Fatalf("Add error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	_, total, err := repos.Albums.GetAlbums(ctx, &models.AlbumFilter{}, 1, 10)
+	if err != nil {
+		t.Fatalf("GetAlbums: %v", err)
+	}
+	if total != 1 {
+		t.Fatalf("albums after failed adds = %d, want 1", total)
+	}
+}
+
+func testAlbumEdit(t *testing.T, repos *Repos) {
+	ctx := context.Background()
+	groupID, songs := addMuse(t, repos)
+
+	id := mustAddAlbum(t, repos, groupID, "Absolution", songs[0], songs[1])
+	mustAddAlbum(t, repos, groupID, "Drones")
+
+	title, cover := "Absolution XV", "https://example.com/xv.jpg"
+	tracks := []int{songs[2], songs[1], songs[0]}
+	err := repos.Albums.Edit(ctx, id, &models.EditAlbumRequest{Title: &title, CoverLink: &cover, Tracks: &tracks})
+	if err != nil {
+		t.Fatalf("Edit: %v", err)
+	}
+
+	a := mustGetAlbum(t, repos, id)
+	if a.Title != title || a.CoverLink != cover || a.ReleaseDate != "16.07.2006" {
+		t.Errorf("album after Edit = %+v", a)
+	}
+	if got := trackSongs(t, a); !equalInts(got, tracks) || a.Tracks[2].Number != 3 {
+		t.Errorf("tracks after Edit = %+v, want songs %v numbered from 1", a.Tracks, tracks)
+	}
+
+	taken := "Drones"
+	if err = repos.Albums.Edit(ctx, id, &models.EditAlbumRequest{Title: &taken}); !errors.Is(err, models.ErrAlbumExists) {
+		t.Fatalf("rename to existing title error = %v, want ErrAlbumExists", err)
+	}
+
+	missing := []int{songs[0], songs[0] + 100}
+	if err = repos.Albums.Edit(ctx, id, &models.EditAlbumRequest{Tracks: &missing}); !errors.Is(err, models.ErrSongNotFound) {
+		t.Fatalf("Edit with missing song error = %v, want ErrSongNotFound", err)
+	}
+	if got := trackSongs(t, mustGetAlbum(t, repos, id)); !equalInts(got, tracks) {
+		t.Fatalf("tracks after failed Edit = %v, want %v", got, tracks)
+	}
+}
+
+func testGetAlbums(t *testing.T, repos *Repos) {
+	museID, _ := addMuse(t, repos)
+	mustAdd(t, repos.Songs, newSong("Queen", "Bohemian Rhapsody", "one"))
+	queenSongs, _ := mustGetSongs(t, repos.Songs, &models.SongFilter{Group: "Queen"}, 1, 1)
+	queenID := queenSongs[0].GroupID
+
+	absolution := mustAddAlbum(t, repos, museID, "Absolution")
+	night := mustAddAlbum(t, repos, queenID, "A Night at the Opera")
+	drones := mustAddAlbum(t, repos, museID, "Drones")
+
+	tests := []struct {
+		name        string
+		filter      models.AlbumFilter
+		page, limit int
+		want        []int
+		total       int
+	}{
+		{"all", models.AlbumFilter{}, 1, 10, []int{absolution, night, drones}, 3},
+		{"second page", models.AlbumFilter{}, 2, 2, []int{drones}, 3},
+		{"group", models.AlbumFilter{GroupID: museID}, 1, 10, []int{absolution, drones}, 2},
+		{"title", models.AlbumFilter{Title: "OPERA"}, 1, 10, []int{night}, 1},
+		{"group and title", models.AlbumFilter{GroupID: queenID, Title: "drones"}, 1, 10, []int{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total, err := repos.Albums.GetAlbums(context.Background(), &tt.filter, tt.page, tt.limit)
+			if err != nil {
+				t.Fatalf("GetAlbums: %v", err)
+			}
+			if total != tt.total {
+				t.Errorf("total = %d, want %d", total, tt.total)
+			}
+
+			ids := make([]int, 0, len(got))
+			for _, a := range got {
+				ids = append(ids, a.ID)
+				if a.GroupName == "" {
+					t.Errorf("album %d has no group name", a.ID)
+				}
+			}
+			if !equalInts(ids, tt.want) {
+				t.Fatalf("ids = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func testGetSongsByAlbum(t *testing.T, repos *Repos) {
+	groupID, songs := addMuse(t, repos)
+	mustAdd(t, repos.Songs, newSong("Queen", "Bohemian Rhapsody", "one"))
+
+	id := mustAddAlbum(t, repos, groupID, "Absolution", songs[2], songs[0], songs[1])
+	mustAddAlbum(t, repos, groupID, "Singles", songs[0])
+
+	filter := &models.SongFilter{AlbumID: id, SortBy: "track_number"}
+	got, total := mustGetSongs(t, repos.Songs, filter, 1, 10)
+	if total != 3 {
+		t.Fatalf("total = %d, want 3", total)
+	}
+	if ids := songIDs(got); !equalInts(ids, []int{songs[2], songs[0], songs[1]}) {
+		t.Fatalf("songs by track = %v", ids)
+	}
+	for i, s := range got {
+		if s.TrackNumber == nil || *s.TrackNumber != i+1 {
+			t.Fatalf("song %d track number = %v, want %d", s.ID, s.TrackNumber, i+1)
+		}
+	}
+
+	desc := &models.SongFilter{AlbumID: id, SortBy: "track_number", SortDesc: true}
+	first, _, err := repos.Songs.GetSongs(context.Background(), desc, &models.Page{Limit: 2})
+	if err != nil {
+		t.Fatalf("GetSongs: %v", err)
+	}
+	last := first[len(first)-1]
+	cursor := &models.Cursor{Key: last.SortValue("track_number"), ID: last.ID, SortBy: "track_number", Desc: true}
+	rest, _, err := repos.Songs.GetSongs(context.Background(), desc, &models.Page{Limit: 2, Cursor: cursor})
+	if err != nil {
+		t.Fatalf("GetSongs after cursor: %v", err)
+	}
+	if ids := songIDs(append(first, rest...)); !equalInts(ids, []int{songs[1], songs[0], songs[2]}) {
+		t.Fatalf("keyset pages by track desc = %v", ids)
+	}
+
+	all, _ := mustGetSongs(t, repos.Songs, &models.SongFilter{}, 1, 10)
+	for _, s := range all {
+		if s.TrackNumber != nil {
+			t.Fatalf("song %d has track number %d without album filter", s.ID, *s.TrackNumber)
+		}
+	}
+}
+
+func testAlbumDeleteSong(t *testing.T, repos *Repos) {
+	ctx := context.Background()
+	groupID, songs := addMuse(t, repos)
+	id := mustAddAlbum(t, repos, groupID, "Absolution", songs...)
+
+	if err := repos.Songs.Delete(ctx, songs[1]); err != nil {
+		t.Fatalf("Delete song: %v", err)
+	}
+
+	a := mustGetAlbum(t, repos, id)
+	if got := trackSongs(t, a); !equalInts(got, []int{songs[0], songs[2]}) {
+		t.Fatalf("tracks after song delete = %v", got)
+	}
+	if a.Tracks[1].Number != 3 {
+		t.Errorf("remaining track number = %d, want 3", a.Tracks[1].Number)
+	}
+
+	if err := repos.Songs.RestoreSong(ctx, songs[1]); err != nil {
+		t.Fatalf("RestoreSong: %v", err)
+	}
+	if got := trackSongs(t, mustGetAlbum(t, repos, id)); !equalInts(got, songs) {
+		t.Fatalf("tracks after restore = %v, want %v", got, songs)
+	}
+	if err := repos.Songs.Delete(ctx, songs[1]); err != nil {
+		t.Fatalf("Delete song: %v", err)
+	}
+
+	if err := repos.Groups.Delete(ctx, groupID); !errors.Is(err, models.ErrGroupNotEmpty) {
+		t.Fatalf("Delete group with songs error = %v, want ErrGroupNotEmpty", err)
+	}
+	for _, songID := range []int{songs[0], songs[2]} {
+		if err := repos.Songs.Delete(ctx, songID); err != nil {
+			t.Fatalf("Delete song: %v", err)
+		}
+	}
+	if err := repos.Groups.Delete(ctx, groupID); !errors.Is(err, models.ErrGroupNotEmpty) {
+		t.Fatalf("Delete group with an album error = %v, want ErrGroupNotEmpty", err)
+	}
+}
+
+func testAlbumDelete(t *testing.T, repos *Repos) {
+	ctx := context.Background()
+	groupID, songs := addMuse(t, repos)
+	id := mustAddAlbum(t, repos, groupID, "Absolution", songs...)
+
+	if err := repos.Albums.Delete(ctx, id); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	exists, err := repos.Albums.AlbumExists(ctx, id)
+	if err != nil {
+		t.Fatalf("AlbumExists: %v", err)
+	}
+	if exists {
+		t.Fatal("AlbumExists after Delete = true")
+	}
+
+	if _, total := mustGetSongs(t, repos.Songs, &models.SongFilter{}, 1, 10); total != 3 {
+		t.Fatalf("songs after album delete = %d, want 3", total)
+	}
+	if err = repos.Groups.Delete(ctx, groupID); !errors.Is(err, models.ErrGroupNotEmpty) {
+		t.Fatalf("Delete group error = %v, want ErrGroupNotEmpty", err)
+	}
+}
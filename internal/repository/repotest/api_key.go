@@ -0,0 +1,106 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+// RunAPIKeyRepo runs the auth.KeyRepo contract.
+func RunAPIKeyRepo(t *testing.T, newRepos ReposFactory) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repos *Repos)
+	}{
+		{"AddAndLookup", testAPIKeyAddAndLookup},
+		{"Revoke", testAPIKeyRevoke},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepos(t))
+		})
+	}
+}
+
+func mustAddAPIKey(t *testing.T, repos *Repos, name, hash string, scopes ...string) int {
+	t.Helper()
+
+	userID := mustAddUser(t, repos, "admin-"+name)
+	key := &models.APIKey{
+		Name:      name,
+		Prefix:    "mlk_" + name,
+		KeyHash:   hash,
+		Scopes:    scopes,
+		CreatedBy: &userID,
+	}
+
+	id, err := repos.Keys.AddAPIKey(context.Background(), key)
+	if err != nil {
+		t.Fatalf("AddAPIKey(%q): %v", name, err)
+	}
+	return id
+}
+
+func testAPIKeyAddAndLookup(t *testing.T, repos *Repos) {
+	ctx := context.Background()
+
+	first := mustAddAPIKey(t, repos, "importer", "hash-1", models.ScopeSongsRead, models.ScopeSongsWrite)
+	second := mustAddAPIKey(t, repos, "reader", "hash-2", models.ScopeSongsRead)
+
+	key, err := repos.Keys.GetAPIKeyByHash(ctx, "hash-1")
+	if err != nil {
+		t.Fatalf("GetAPIKeyByHash: %v", err)
+	}
+	if key == nil || key.ID != first || key.Name != "importer" || key.RevokedAt != nil {
+		t.Fatalf("GetAPIKeyByHash = %+v, want active key %d", key, first)
+	}
+	if !slices.Equal(key.Scopes, models.Scopes{models.ScopeSongsRead, models.ScopeSongsWrite}) {
+		t.Fatalf("scopes = %v", key.Scopes)
+	}
+	if key.CreatedBy == nil || key.CreatedAt == "" {
+		t.Fatalf("GetAPIKeyByHash = %+v, want creator and creation time", key)
+	}
+
+	key, err = repos.Keys.GetAPIKeyByHash(ctx, "missing")
+	if err != nil || key != nil {
+		t.Fatalf("GetAPIKeyByHash(missing) = %+v, %v, want nil, nil", key, err)
+	}
+
+	keys, err := repos.Keys.GetAPIKeys(ctx)
+	if err != nil {
+		t.Fatalf("GetAPIKeys: %v", err)
+	}
+	if len(keys) != 2 || keys[0].ID != first || keys[1].ID != second {
+		t.Fatalf("GetAPIKeys = %+v, want keys %d and %d", keys, first, second)
+	}
+}
+
+func testAPIKeyRevoke(t *testing.T, repos *Repos) {
+	ctx := context.Background()
+	now := time.Now()
+
+	id := mustAddAPIKey(t, repos, "importer", "hash-1", models.ScopeSongsWrite)
+
+	if err := repos.Keys.RevokeAPIKey(ctx, id, now); err != nil {
+		t.Fatalf("RevokeAPIKey: %v", err)
+	}
+	if err := repos.Keys.RevokeAPIKey(ctx, id, now.Add(time.Hour)); err != nil {
+		t.Fatalf("RevokeAPIKey(again): %v", err)
+	}
+	if err := repos.Keys.RevokeAPIKey(ctx, id+100, now); !errors.Is(err, models.ErrAPIKeyNotFound) {
+		t.Fatalf("RevokeAPIKey(missing) error = %v, want %v", err, models.ErrAPIKeyNotFound)
+	}
+
+	key, err := repos.Keys.GetAPIKeyByHash(ctx, "hash-1")
+	if err != nil {
+		t.Fatalf("GetAPIKeyByHash: %v", err)
+	}
+	if key == nil || key.RevokedAt == nil {
+		t.Fatalf("GetAPIKeyByHash = %+v, want revoked key", key)
+	}
+}
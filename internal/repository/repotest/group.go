@@ -0,0 +1,254 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/album"
+	"github.com/LionJr/music-library/internal/service/auth"
+	"github.com/LionJr/music-library/internal/service/group"
+	"github.com/LionJr/music-library/internal/service/playlist"
+	"github.com/LionJr/music-library/internal/service/song"
+)
+
+// Repos are repositories backed by the same empty storage.
+type Repos struct {
+	Songs     song.Repo
+	Groups    group.Repo
+	Albums    album.Repo
+	Playlists playlist.Repo
+	Users     auth.Repo
+	Keys      auth.KeyRepo
+}
+
+// ReposFactory returns fresh Repos. It is called once per subtest.
+type ReposFactory func(t *testing.T) *Repos
+
+// RunGroupRepo runs the group.Repo contract, including how groups are
+// resolved when songs are added.
+func RunGroupRepo(t *testing.T, newRepos ReposFactory) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, songs song.Repo, groups group.Repo)
+	}{
+		{"AddDuplicate", testGroupAddDuplicate},
+		{"SongsShareGroup", testSongsShareGroup},
+		{"Rename", testGroupRename},
+		{"DeleteNotEmpty", testGroupDeleteNotEmpty},
+		{"GetGroups", testGetGroups},
+		{"GetGroupSongs", testGetGroupSongs},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := newRepos(t)
+			tt.run(t, repos.Songs, repos.Groups)
+		})
+	}
+}
+
+func mustAddGroup(t *testing.T, repo group.Repo, name string) int {
+	t.Helper()
+
+	id, err := repo.Add(context.Background(), name)
+	if err != nil {
+		t.Fatalf("Add(%q): %v", name, err)
+	}
+	return id
+}
+
+func mustGetGroup(t *testing.T, repo group.Repo, id int) *models.Group {
+	t.Helper()
+
+	g, err := repo.GetGroup(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetGroup(%d): %v", id, err)
+	}
+	return g
+}
+
+func testGroupAddDuplicate(t *testing.T, _ song.Repo, groups group.Repo) {
+	ctx := context.Background()
+
+	id := mustAddGroup(t, groups, "Muse")
+	if g := mustGetGroup(t, groups, id); g == nil || g.Name != "Muse" {
+		t.Fatalf("GetGroup(%d) = %+v, want Muse", id, g)
+	}
+
+	if _, err := groups.Add(ctx, "Muse"); !errors.Is(err, models.ErrGroupExists) {
+		t.Fatalf("second Add error = %v, want ErrGroupExists", err)
+	}
+
+	if g := mustGetGroup(t, groups, id+100); g != nil {
+		t.Fatalf("GetGroup(missing) = %+v, want nil", g)
+	}
+}
+
+func testSongsShareGroup(t *testing.T, songs song.Repo, groups group.Repo) {
+	existing := mustAddGroup(t, groups, "Muse")
+
+	first := mustAdd(t, songs, newSong("Muse", "Hysteria", "one"))
+	second := mustAdd(t, songs, newSong("Muse", "Uprising", "two"))
+	mustAdd(t, songs, newSong("Queen", "Bohemian Rhapsody", "three"))
+
+	got, _ := mustGetSongs(t, songs, &models.SongFilter{}, 1, 10)
+	groupIDs := make(map[int]int, len(got))
+	for _, s := range got {
+		groupIDs[s.ID] = s.GroupID
+	}
+	if groupIDs[first] != existing || groupIDs[second] != existing {
+		t.Fatalf("songs of Muse got groups %d and %d, want %d", groupIDs[first], groupIDs[second], existing)
+	}
+
+	all, total, err := groups.GetGroups(context.Background(), "", 1, 10)
+	if err != nil {
+		t.Fatalf("GetGroups: %v", err)
+	}
+	if total != 2 || len(all) != 2 {
+		t.Fatalf("GetGroups = %d groups (total %d), want 2", len(all), total)
+	}
+}
+
+func testGroupRename(t *testing.T, songs song.Repo, groups group.Repo) {
+	ctx := context.Background()
+
+	mustAdd(t, songs, newSong("Muse", "Hysteria", "one"))
+	other := mustAddGroup(t, groups, "Queen")
+
+	got, _ := mustGetSongs(t, songs, &models.SongFilter{}, 1, 10)
+	id := got[0].GroupID
+
+	name := "MUSE"
+	if err := groups.Edit(ctx, id, &models.EditGroupRequest{Name: &name}); err != nil {
+		t.Fatalf("Edit: %v", err)
+	}
+
+	version := got[0].Version
+	got, _ = mustGetSongs(t, songs, &models.SongFilter{}, 1, 10)
+	if got[0].GroupName != "MUSE" {
+		t.Fatalf("song group after rename = %q, want MUSE", got[0].GroupName)
+	}
+	if got[0].Version != version+1 {
+		t.Fatalf("song version after rename = %d, want %d", got[0].Version, version+1)
+	}
+
+	taken := "Queen"
+	if err := groups.Edit(ctx, id, &models.EditGroupRequest{Name: &taken}); !errors.Is(err, models.ErrGroupExists) {
+		t.Fatalf("rename to existing name error = %v, want ErrGroupExists", err)
+	}
+	if g := mustGetGroup(t, groups, other); g == nil || g.Name != "Queen" {
+		t.Fatalf("GetGroup(%d) = %+v, want Queen", other, g)
+	}
+}
+
+func testGroupDeleteNotEmpty(t *testing.T, songs song.Repo, groups group.Repo) {
+	ctx := context.Background()
+
+	songID := mustAdd(t, songs, newSong("Muse", "Hysteria", "one"))
+	got, _ := mustGetSongs(t, songs, &models.SongFilter{}, 1, 10)
+	id := got[0].GroupID
+
+	if err := groups.Delete(ctx, id); !errors.Is(err, models.ErrGroupNotEmpty) {
+		t.Fatalf("Delete non-empty group error = %v, want ErrGroupNotEmpty", err)
+	}
+
+	if err := songs.Delete(ctx, songID); err != nil {
+		t.Fatalf("Delete song: %v", err)
+	}
+	if err := groups.Delete(ctx, id); !errors.Is(err, models.ErrGroupNotEmpty) {
+		t.Fatalf("Delete group with a song in the trash error = %v, want ErrGroupNotEmpty", err)
+	}
+
+	// SQLite keeps deleted_at to the second.
+	time.Sleep(1100 * time.Millisecond)
+
+	if purged, err := songs.PurgeTrash(ctx, 0); err != nil || purged != 1 {
+		t.Fatalf("PurgeTrash(0) = %d, %v, want 1", purged, err)
+	}
+	if err := groups.Delete(ctx, id); err != nil {
+		t.Fatalf("Delete empty group: %v", err)
+	}
+
+	exists, err := groups.GroupExists(ctx, id)
+	if err != nil {
+		t.Fatalf("GroupExists: %v", err)
+	}
+	if exists {
+		t.Fatal("GroupExists after Delete = true")
+	}
+}
+
+func testGetGroups(t *testing.T, _ song.Repo, groups group.Repo) {
+	for _, name := range []string{"Queen", "Muse", "Queens of the Stone Age", "ABBA"} {
+		mustAddGroup(t, groups, name)
+	}
+
+	tests := []struct {
+		name        string
+		filter      string
+		page, limit int
+		want        []string
+		total       int
+	}{
+		{"all", "", 1, 10, []string{"ABBA", "Muse", "Queen", "Queens of the Stone Age"}, 4},
+		{"second page", "", 2, 3, []string{"Queens of the Stone Age"}, 4},
+		{"substring", "quee", 1, 10, []string{"Queen", "Queens of the Stone Age"}, 2},
+		{"no match", "zz", 1, 10, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total, err := groups.GetGroups(context.Background(), tt.filter, tt.page, tt.limit)
+			if err != nil {
+				t.Fatalf("GetGroups: %v", err)
+			}
+			if total != tt.total {
+				t.Errorf("total = %d, want %d", total, tt.total)
+			}
+
+			names := make([]string, 0, len(got))
+			for _, g := range got {
+				names = append(names, g.Name)
+			}
+			if len(names) != len(tt.want) {
+				t.Fatalf("names = %v, want %v", names, tt.want)
+			}
+			for i := range names {
+				if names[i] != tt.want[i] {
+					t.Fatalf("names = %v, want %v", names, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func testGetGroupSongs(t *testing.T, songs song.Repo, groups group.Repo) {
+	first := mustAdd(t, songs, newSong("Muse", "Hysteria", "one"))
+	mustAdd(t, songs, newSong("Queen", "Bohemian Rhapsody", "two"))
+	second := mustAdd(t, songs, newSong("Muse", "Uprising", "three"))
+
+	got, _ := mustGetSongs(t, songs, &models.SongFilter{Group: "Muse"}, 1, 10)
+	id := got[0].GroupID
+
+	page, total, err := groups.GetGroupSongs(context.Background(), id, 1, 1)
+	if err != nil {
+		t.Fatalf("GetGroupSongs: %v", err)
+	}
+	if total != 2 {
+		t.Fatalf("total = %d, want 2", total)
+	}
+	if len(page) != 1 || page[0].ID != first || page[0].GroupName != "Muse" {
+		t.Fatalf("first page = %+v, want song %d of Muse", page, first)
+	}
+
+	page, _, err = groups.GetGroupSongs(context.Background(), id, 2, 1)
+	if err != nil {
+		t.Fatalf("GetGroupSongs: %v", err)
+	}
+	if len(page) != 1 || page[0].ID != second {
+		t.Fatalf("second page = %+v, want song %d", page, second)
+	}
+}
@@ -0,0 +1,332 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+// RunPlaylistRepo runs the playlist.Repo contract, including what happens to
+// items when their songs are deleted through song.Repo.
+func RunPlaylistRepo(t *testing.T, newRepos ReposFactory) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repos *Repos)
+	}{
+		{"Playlists", testPlaylists},
+		{"AddItem", testPlaylistAddItem},
+		{"MoveItem", testPlaylistMoveItem},
+		{"RemoveItem", testPlaylistRemoveItem},
+		{"DeleteSong", testPlaylistDeleteSong},
+		{"GetItemsKeyset", testPlaylistItemsKeyset},
+		{"Owners", testPlaylistOwners},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepos(t))
+		})
+	}
+}
+
+func mustAddPlaylist(t *testing.T, repos *Repos, owner int, name string, songIDs ...int) int {
+	t.Helper()
+
+	ctx := context.Background()
+	id, err := repos.Playlists.Add(ctx, owner, name)
+	if err != nil {
+		t.Fatalf("Add(%q): %v", name, err)
+	}
+
+	for _, songID := range songIDs {
+		if _, err = repos.Playlists.AddItem(ctx, id, songID); err != nil {
+			t.Fatalf("AddItem(%d, %d): %v", id, songID, err)
+		}
+	}
+
+	return id
+}
+
+// mustGetItems returns the song ids of the whole playlist, checking that
+// positions count from 1.
+func mustGetItems(t *testing.T, repos *Repos, id int) []int {
+	t.Helper()
+
+	items, total, err := repos.Playlists.GetItems(context.Background(), id, &models.Page{Limit: 100})
+	if err != nil {
+		t.Fatalf("GetItems(%d): %v", id, err)
+	}
+	if total != len(items) {
+		t.Fatalf("GetItems(%d) total = %d, want %d", id, total, len(items))
+	}
+
+	songs := make([]int, 0, len(items))
+	for i, item := range items {
+		if item.Position != i+1 {
+			t.Fatalf("item %d position = %d, want %d", item.ItemID, item.Position, i+1)
+		}
+		songs = append(songs, item.ID)
+	}
+	return songs
+}
+
+func testPlaylists(t *testing.T, repos *Repos) {
+	ctx := context.Background()
+	owner := mustAddUser(t, repos, "alice")
+
+	first := mustAddPlaylist(t, repos, owner, "Road trip")
+	second := mustAddPlaylist(t, repos, owner, "Workout")
+
+	name := "Long road trip"
+	if err := repos.Playlists.Edit(ctx, first, &models.EditPlaylistRequest{Name: &name}); err != nil {
+		t.Fatalf("Edit: %v", err)
+	}
+
+	p, err := repos.Playlists.GetPlaylist(ctx, owner, first)
+	if err != nil {
+		t.Fatalf("GetPlaylist: %v", err)
+	}
+	if p == nil || p.Name != name || p.UserID != owner {
+		t.Fatalf("GetPlaylist(%d) = %+v, want %q", first, p, name)
+	}
+
+	page, total, err := repos.Playlists.GetPlaylists(ctx, owner, 2, 1)
+	if err != nil {
+		t.Fatalf("GetPlaylists: %v", err)
+	}
+	if total != 2 || len(page) != 1 || page[0].ID != second {
+		t.Fatalf("GetPlaylists page 2 = %+v (total %d), want playlist %d of 2", page, total, second)
+	}
+
+	if err = repos.Playlists.Delete(ctx, first); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if p, err = repos.Playlists.GetPlaylist(ctx, owner, first); err != nil || p != nil {
+		t.Fatalf("GetPlaylist after Delete = %+v, %v; want nil", p, err)
+	}
+}
+
+func testPlaylistAddItem(t *testing.T, repos *Repos) {
+	ctx := context.Background()
+	_, songs := addMuse(t, repos)
+	owner := mustAddUser(t, repos, "alice")
+	id := mustAddPlaylist(t, repos, owner, "Road trip")
+
+	for i, songID := range []int{songs[1], songs[0], songs[1]} {
+		position, err := repos.Playlists.AddItem(ctx, id, songID)
+		if err != nil {
+			t.Fatalf("AddItem(%d): %v", songID, err)
+		}
+		if position != i+1 {
+			t.Fatalf("AddItem(%d) position = %d, want %d", songID, position, i+1)
+		}
+	}
+
+	if _, err := repos.Playlists.AddItem(ctx, id, songs[2]+100); !errors.Is(err, models.ErrSongNotFound) {
+		t.Fatalf("AddItem(missing song) error = %v, want ErrSongNotFound", err)
+	}
+
+	if got := mustGetItems(t, repos, id); !equalInts(got, []int{songs[1], songs[0], songs[1]}) {
+		t.Fatalf("items = %v", got)
+	}
+
+	items, _, err := repos.Playlists.GetItems(ctx, id, &models.Page{Limit: 1})
+	if err != nil {
+		t.Fatalf("GetItems: %v", err)
+	}
+	if items[0].SongName != "Time Is Running Out" || items[0].GroupName != "Muse" {
+		t.Fatalf("first item = %+v, want song details", items[0])
+	}
+}
+
+func testPlaylistMoveItem(t *testing.T, repos *Repos) {
+	ctx := context.Background()
+	_, songs := addMuse(t, repos)
+	owner := mustAddUser(t, repos, "alice")
+	extra := mustAdd(t, repos.Songs, newSong("Queen", "Bohemian Rhapsody", "four"))
+	all := []int{songs[0], songs[1], songs[2], extra}
+
+	tests := []struct {
+		name     string
+		from, to int
+		want     []int
+	}{
+		{"down", 1, 3, []int{songs[1], songs[2], songs[0], extra}},
+		{"up", 4, 1, []int{extra, songs[0], songs[1], songs[2]}},
+		{"adjacent", 2, 3, []int{songs[0], songs[2], songs[1], extra}},
+		{"same position", 2, 2, all},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := mustAddPlaylist(t, repos, owner, tt.name, all...)
+
+			if err := repos.Playlists.MoveItem(ctx, id, tt.from, tt.to); err != nil {
+				t.Fatalf("MoveItem(%d, %d): %v", tt.from, tt.to, err)
+			}
+			if got := mustGetItems(t, repos, id); !equalInts(got, tt.want) {
+				t.Fatalf("items = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	id := mustAddPlaylist(t, repos, owner, "out of range", all...)
+	for _, move := range [][2]int{{0, 1}, {1, 5}, {5, 1}} {
+		if err := repos.Playlists.MoveItem(ctx, id, move[0], move[1]); !errors.Is(err, models.ErrPlaylistItemNotFound) {
+			t.Fatalf("MoveItem(%d, %d) error = %v, want ErrPlaylistItemNotFound", move[0], move[1], err)
+		}
+	}
+	if got := mustGetItems(t, repos, id); !equalInts(got, all) {
+		t.Fatalf("items after failed moves = %v, want %v", got, all)
+	}
+}
+
+func testPlaylistRemoveItem(t *testing.T, repos *Repos) {
+	ctx := context.Background()
+	_, songs := addMuse(t, repos)
+	owner := mustAddUser(t, repos, "alice")
+	id := mustAddPlaylist(t, repos, owner, "Road trip", songs...)
+
+	if err := repos.Playlists.RemoveItem(ctx, id, 2); err != nil {
+		t.Fatalf("RemoveItem(2): %v", err)
+	}
+	if got := mustGetItems(t, repos, id); !equalInts(got, []int{songs[0], songs[2]}) {
+		t.Fatalf("items = %v", got)
+	}
+
+	if err := repos.Playlists.RemoveItem(ctx, id, 3); !errors.Is(err, models.ErrPlaylistItemNotFound) {
+		t.Fatalf("RemoveItem(3) error = %v, want ErrPlaylistItemNotFound", err)
+	}
+
+	position, err := repos.Playlists.AddItem(ctx, id, songs[1])
+	if err != nil {
+		t.Fatalf("AddItem: %v", err)
+	}
+	if position != 3 {
+		t.Fatalf("AddItem after remove position = %d, want 3", position)
+	}
+}
+
+func testPlaylistDeleteSong(t *testing.T, repos *Repos) {
+	ctx := context.Background()
+	_, songs := addMuse(t, repos)
+	owner := mustAddUser(t, repos, "alice")
+	id := mustAddPlaylist(t, repos, owner, "Road trip", songs[0], songs[1], songs[2], songs[1])
+
+	if err := repos.Songs.Delete(ctx, songs[1]); err != nil {
+		t.Fatalf("Delete song: %v", err)
+	}
+	if got := mustGetItems(t, repos, id); !equalInts(got, []int{songs[0], songs[2]}) {
+		t.Fatalf("items after song delete = %v", got)
+	}
+
+	if err := repos.Playlists.MoveItem(ctx, id, 2, 1); err != nil {
+		t.Fatalf("MoveItem after song delete: %v", err)
+	}
+	if got := mustGetItems(t, repos, id); !equalInts(got, []int{songs[2], songs[0]}) {
+		t.Fatalf("items after move = %v", got)
+	}
+
+	if _, err := repos.Playlists.AddItem(ctx, id, songs[1]); !errors.Is(err, models.ErrSongNotFound) {
+		t.Fatalf("AddItem of a deleted song error = %v, want ErrSongNotFound", err)
+	}
+
+	// Restoring the song brings its items back in the places they kept.
+	if err := repos.Songs.RestoreSong(ctx, songs[1]); err != nil {
+		t.Fatalf("RestoreSong: %v", err)
+	}
+	if got := mustGetItems(t, repos, id); !equalInts(got, []int{songs[2], songs[1], songs[0], songs[1]}) {
+		t.Fatalf("items after restore = %v", got)
+	}
+
+	if err := repos.Songs.Delete(ctx, songs[1]); err != nil {
+		t.Fatalf("Delete song: %v", err)
+	}
+	if err := repos.Playlists.RemoveItem(ctx, id, 2); err != nil {
+		t.Fatalf("RemoveItem after song delete: %v", err)
+	}
+	if got := mustGetItems(t, repos, id); !equalInts(got, []int{songs[2]}) {
+		t.Fatalf("items after remove = %v", got)
+	}
+
+	// SQLite keeps deleted_at to the second.
+	time.Sleep(1100 * time.Millisecond)
+
+	if purged, err := repos.Songs.PurgeTrash(ctx, 0); err != nil || purged != 1 {
+		t.Fatalf("PurgeTrash(0) = %d, %v, want 1", purged, err)
+	}
+	position, err := repos.Playlists.AddItem(ctx, id, songs[0])
+	if err != nil {
+		t.Fatalf("AddItem after purge: %v", err)
+	}
+	if position != 2 {
+		t.Errorf("AddItem after purge position = %d, want 2", position)
+	}
+}
+
+func testPlaylistItemsKeyset(t *testing.T, repos *Repos) {
+	ctx := context.Background()
+	_, songs := addMuse(t, repos)
+	owner := mustAddUser(t, repos, "alice")
+	id := mustAddPlaylist(t, repos, owner, "Road trip", songs[2], songs[0], songs[1], songs[0])
+
+	first, _, err := repos.Playlists.GetItems(ctx, id, &models.Page{Limit: 2})
+	if err != nil {
+		t.Fatalf("GetItems: %v", err)
+	}
+
+	last := first[len(first)-1]
+	next := &models.Cursor{Key: strconv.Itoa(last.SortKey), ID: last.ItemID}
+	second, _, err := repos.Playlists.GetItems(ctx, id, &models.Page{Limit: 2, Cursor: next})
+	if err != nil {
+		t.Fatalf("GetItems after cursor: %v", err)
+	}
+	if len(second) != 2 || second[0].Position != 3 || second[1].Position != 4 {
+		t.Fatalf("second page = %+v, want positions 3 and 4", second)
+	}
+
+	prev := &models.Cursor{Key: strconv.Itoa(second[0].SortKey), ID: second[0].ItemID, Backward: true}
+	back, _, err := repos.Playlists.GetItems(ctx, id, &models.Page{Limit: 2, Cursor: prev})
+	if err != nil {
+		t.Fatalf("GetItems before cursor: %v", err)
+	}
+
+	ids := make([]int, 0, 4)
+	for _, item := range append(back, second...) {
+		ids = append(ids, item.ID)
+	}
+	if !equalInts(ids, []int{songs[2], songs[0], songs[1], songs[0]}) {
+		t.Fatalf("pages = %v", ids)
+	}
+}
+
+func testPlaylistOwners(t *testing.T, repos *Repos) {
+	ctx := context.Background()
+	alice := mustAddUser(t, repos, "alice")
+	bob := mustAddUser(t, repos, "bob")
+	mine := mustAddPlaylist(t, repos, alice, "Road trip")
+	theirs := mustAddPlaylist(t, repos, bob, "Workout")
+
+	if exists, err := repos.Playlists.PlaylistExists(ctx, alice, mine); err != nil || !exists {
+		t.Fatalf("PlaylistExists(own) = %v, %v; want true", exists, err)
+	}
+	if exists, err := repos.Playlists.PlaylistExists(ctx, alice, theirs); err != nil || exists {
+		t.Fatalf("PlaylistExists(other user's) = %v, %v; want false", exists, err)
+	}
+
+	if p, err := repos.Playlists.GetPlaylist(ctx, alice, theirs); err != nil || p != nil {
+		t.Fatalf("GetPlaylist(other user's) = %+v, %v; want nil", p, err)
+	}
+
+	page, total, err := repos.Playlists.GetPlaylists(ctx, alice, 1, 10)
+	if err != nil {
+		t.Fatalf("GetPlaylists: %v", err)
+	}
+	if total != 1 || len(page) != 1 || page[0].ID != mine {
+		t.Fatalf("GetPlaylists = %+v (total %d), want only playlist %d", page, total, mine)
+	}
+}
@@ -0,0 +1,969 @@
+// Package repotest holds a conformance suite that every song.Repo
+// implementation is expected to pass.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/song"
+)
+
+// Factory returns an empty repository. It is called once per subtest.
+type Factory func(t *testing.T) song.Repo
+
+// RunSongRepo runs the song.Repo contract against the repositories produced
+// by newRepo.
+func RunSongRepo(t *testing.T, newRepo Factory) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo song.Repo)
+	}{
+		{"Add", testAdd},
+		{"AddDuplicate", testAddDuplicate},
+		{"ImportSongs", testImportSongs},
+		{"ExportSongs", testExportSongs},
+		{"Delete", testDelete},
+		{"Trash", testTrash},
+		{"Edit", testEdit},
+		{"EditMissingVerse", testEditMissingVerse},
+		{"EditVersion", testEditVersion},
+		{"ManageVerses", testManageVerses},
+		{"ReplaceText", testReplaceText},
+		{"Revisions", testRevisions},
+		{"GetSong", testGetSong},
+		{"GetSongsFilters", testGetSongsFilters},
+		{"GetSongsPagination", testGetSongsPagination},
+		{"GetSongsKeyset", testGetSongsKeyset},
+		{"GetSongVerses", testGetSongVerses},
+		{"GetSongVersesKeyset", testGetSongVersesKeyset},
+		{"SearchVerses", testSearchVerses},
+		{"Exists", testExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepo(t))
+		})
+	}
+}
+
+func newSong(group, name, text string) *models.Song {
+	return &models.Song{
+		GroupName:   group,
+		SongName:    name,
+		ReleaseDate: "2006-07-16",
+		Link:        "https://example.com/" + name,
+		Text:        text,
+	}
+}
+
+func mustAdd(t *testing.T, repo song.Repo, s *models.Song) int {
+	t.Helper()
+
+	id, err := repo.Add(context.Background(), s)
+	if err != nil {
+		t.Fatalf("Add(%q, %q): %v", s.GroupName, s.SongName, err)
+	}
+	return id
+}
+
+func mustGetSongs(t *testing.T, repo song.Repo, filter *models.SongFilter, page, limit int) ([]models.Song, int) {
+	t.Helper()
+
+	songs, total, err := repo.GetSongs(context.Background(), filter, &models.Page{Offset: (page - 1) * limit, Limit: limit})
+	if err != nil {
+		t.Fatalf("GetSongs(%+v, %d, %d): %v", filter, page, limit, err)
+	}
+	return songs, total
+}
+
+func mustGetVerses(t *testing.T, repo song.Repo, songId, page, limit int) ([]models.Verse, int) {
+	t.Helper()
+
+	verses, total, err := repo.GetSongVerses(context.Background(), songId, &models.Page{Offset: (page - 1) * limit, Limit: limit})
+	if err != nil {
+		t.Fatalf("GetSongVerses(%d, %d, %d): %v", songId, page, limit, err)
+	}
+	return verses, total
+}
+
+func songIDs(songs []models.Song) []int {
+	ids := make([]int, 0, len(songs))
+	for _, s := range songs {
+		ids = append(ids, s.ID)
+	}
+	return ids
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func testAdd(t *testing.T, repo song.Repo) {
+	first := mustAdd(t, repo, newSong("Muse", "Supermassive Black Hole", "one\ntwo\n\nthree\n\nfour"))
+	second := mustAdd(t, repo, newSong("Muse", "Uprising", "only"))
+
+	if first <= 0 || second <= 0 || first == second {
+		t.Fatalf("Add returned ids %d and %d, want distinct positive ids", first, second)
+	}
+
+	songs, total := mustGetSongs(t, repo, &models.SongFilter{Group: "Muse", Song: "Supermassive Black Hole"}, 1, 10)
+	if total != 1 || len(songs) != 1 {
+		t.Fatalf("GetSongs returned %d songs (total %d), want 1", len(songs), total)
+	}
+
+	got := songs[0]
+	if got.ID != first || got.ReleaseDate != "2006-07-16" || got.Link != "https://example.com/Supermassive Black Hole" {
+		t.Errorf("stored song = %+v", got)
+	}
+
+	verses, total := mustGetVerses(t, repo, first, 1, 10)
+	want := []string{"one\ntwo", "three", "four"}
+	if total != len(want) || len(verses) != len(want) {
+		t.Fatalf("got %d verses (total %d), want %d", len(verses), total, len(want))
+	}
+	for i, verse := range verses {
+		if verse.Index != i+1 || verse.Text != want[i] || verse.SongId != first {
+			t.Errorf("verse %d = %+v, want index %d text %q", i, verse, i+1, want[i])
+		}
+	}
+}
+
+func testAddDuplicate(t *testing.T, repo song.Repo) {
+	mustAdd(t, repo, newSong("Muse", "Uprising", "a"))
+
+	if _, err := repo.Add(context.Background(), newSong("Muse", "Uprising", "b")); !errors.Is(err, models.ErrDuplicate) {
+		t.Fatalf("Add of a duplicate song: %v, want ErrDuplicate", err)
+	}
+
+	other := mustAdd(t, repo, newSong("Other", "Uprising", "c"))
+
+	if _, total := mustGetSongs(t, repo, &models.SongFilter{Song: "Uprising"}, 1, 10); total != 2 {
+		t.Errorf("total songs named Uprising = %d, want 2", total)
+	}
+
+	group := "Muse"
+	if _, err := repo.Edit(context.Background(), other, &models.EditSongRequest{GroupName: &group}, 0); !errors.Is(err, models.ErrSongExists) {
+		t.Errorf("Edit onto a taken name: %v, want ErrSongExists", err)
+	}
+
+	// A song in the trash does not hold on to its name.
+	if err := repo.Delete(context.Background(), other); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	mustAdd(t, repo, newSong("Other", "Uprising", "d"))
+}
+
+func testImportSongs(t *testing.T, repo song.Repo) {
+	ctx := context.Background()
+
+	existing := mustAdd(t, repo, newSong("Muse", "Hysteria", "Verse"))
+
+	ids, err := repo.ImportSongs(ctx, []models.Song{
+		*newSong("Muse", "Starlight", "First\n\nSecond"),
+		*newSong("Muse", "Hysteria", "Again"),
+		*newSong("Queen", "Bohemian Rhapsody", "Is this"),
+		*newSong("Queen", "Bohemian Rhapsody", "The real life"),
+	})
+	if err != nil {
+		t.Fatalf("ImportSongs: %v", err)
+	}
+	if len(ids) != 4 || ids[0] == 0 || ids[1] != 0 || ids[2] == 0 || ids[3] != 0 {
+		t.Fatalf("ImportSongs ids = %v, want new ids for rows 1 and 3 only", ids)
+	}
+	if ids[0] == existing || ids[0] == ids[2] {
+		t.Fatalf("ImportSongs ids = %v, want distinct new ids", ids)
+	}
+
+	if _, total := mustGetSongs(t, repo, &models.SongFilter{}, 1, 10); total != 3 {
+		t.Fatalf("total songs = %d, want 3", total)
+	}
+
+	if verses, total := mustGetVerses(t, repo, ids[0], 1, 10); total != 2 || verses[1].Text != "Second" {
+		t.Fatalf("imported verses = %+v (%d), want two verses", verses, total)
+	}
+}
+
+func testExportSongs(t *testing.T, repo song.Repo) {
+	ctx := context.Background()
+
+	// Enough songs to span several batches of the SQL backends.
+	songs := make([]models.Song, 0, 1201)
+	for i := 0; i < 1200; i++ {
+		songs = append(songs, *newSong("Muse", fmt.Sprintf("Song %04d", i), fmt.Sprintf("Verse %d\n\nChorus", i)))
+	}
+	songs = append(songs, *newSong("Queen", "Other", "Ignored"))
+	if _, err := repo.ImportSongs(ctx, songs); err != nil {
+		t.Fatalf("ImportSongs: %v", err)
+	}
+
+	filter := &models.SongFilter{Group: "muse", SortBy: "song_name", SortDesc: true}
+	want, _ := mustGetSongs(t, repo, filter, 1, 2000)
+
+	var got []models.SongExport
+	err := repo.ExportSongs(ctx, filter, func(s *models.SongExport) error {
+		got = append(got, *s)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExportSongs: %v", err)
+	}
+
+	if len(got) != 1200 || len(want) != 1200 {
+		t.Fatalf("ExportSongs returned %d songs, GetSongs %d, want 1200", len(got), len(want))
+	}
+	for i := range got {
+		if got[i].ID != want[i].ID {
+			t.Fatalf("song %d = %d, want %d in GetSongs order", i, got[i].ID, want[i].ID)
+		}
+		if len(got[i].Verses) != 2 || got[i].Verses[0].Index != 1 || got[i].Verses[1].Text != "Chorus" {
+			t.Fatalf("song %d verses = %+v", got[i].ID, got[i].Verses)
+		}
+		if !strings.HasSuffix(got[i].Text, "\n\nChorus") {
+			t.Fatalf("song %d text = %q", got[i].ID, got[i].Text)
+		}
+	}
+
+	stop := fmt.Errorf("stop")
+	calls := 0
+	err = repo.ExportSongs(ctx, filter, func(*models.SongExport) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Fatalf("ExportSongs with failing emit = %v after %d calls, want stop after 1", err, calls)
+	}
+}
+
+func testDelete(t *testing.T, repo song.Repo) {
+	ctx := context.Background()
+	doomed := mustAdd(t, repo, newSong("Muse", "Uprising", "a\n\nb"))
+	kept := mustAdd(t, repo, newSong("Muse", "Resistance", "c\n\nd"))
+
+	if err := repo.Delete(ctx, doomed); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if exists, err := repo.SongExists(ctx, doomed); err != nil || exists {
+		t.Errorf("SongExists after Delete = %v, %v; want false, nil", exists, err)
+	}
+
+	if verses, total := mustGetVerses(t, repo, doomed, 1, 10); total != 0 || len(verses) != 0 {
+		t.Errorf("verses of deleted song: %d (total %d), want none", len(verses), total)
+	}
+
+	if exists, err := repo.VerseExists(ctx, doomed, 1); err != nil || exists {
+		t.Errorf("VerseExists after Delete = %v, %v; want false, nil", exists, err)
+	}
+
+	if _, total := mustGetVerses(t, repo, kept, 1, 10); total != 2 {
+		t.Errorf("verses of surviving song = %d, want 2", total)
+	}
+
+	if err := repo.Delete(ctx, doomed); err != nil {
+		t.Errorf("second Delete: %v", err)
+	}
+}
+
+func testTrash(t *testing.T, repo song.Repo) {
+	ctx := context.Background()
+	doomed := mustAdd(t, repo, newSong("Muse", "Uprising", "a\n\nb"))
+	kept := mustAdd(t, repo, newSong("Muse", "Resistance", "c"))
+
+	if err := repo.Delete(ctx, doomed); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if songs, total := mustGetSongs(t, repo, &models.SongFilter{}, 1, 10); total != 1 || !equalInts(songIDs(songs), []int{kept}) {
+		t.Errorf("songs after Delete = %v (total %d), want [%d]", songIDs(songs), total, kept)
+	}
+	if _, err := repo.GetSong(ctx, doomed); !errors.Is(err, models.ErrSongNotFound) {
+		t.Errorf("GetSong of a deleted song: %v, want ErrSongNotFound", err)
+	}
+
+	trash, total, err := repo.GetTrash(ctx, 1, 10)
+	if err != nil {
+		t.Fatalf("GetTrash: %v", err)
+	}
+	if total != 1 || len(trash) != 1 || trash[0].ID != doomed || trash[0].GroupName != "Muse" || trash[0].DeletedAt == nil {
+		t.Fatalf("GetTrash = %+v (total %d)", trash, total)
+	}
+
+	// A deleted song does not block adding it again, but then it cannot be
+	// restored next to its replacement.
+	replacement := mustAdd(t, repo, newSong("Muse", "Uprising", "x"))
+	if err = repo.RestoreSong(ctx, doomed); !errors.Is(err, models.ErrSongExists) {
+		t.Errorf("RestoreSong next to a live duplicate: %v, want ErrSongExists", err)
+	}
+
+	if err = repo.Delete(ctx, replacement); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err = repo.RestoreSong(ctx, doomed); err != nil {
+		t.Fatalf("RestoreSong: %v", err)
+	}
+	if got, err := repo.GetSong(ctx, doomed); err != nil || got.Text != "a\n\nb" || got.DeletedAt != nil {
+		t.Errorf("GetSong after RestoreSong = %+v, %v", got, err)
+	}
+
+	for _, id := range []int{doomed, kept} {
+		if err = repo.RestoreSong(ctx, id); !errors.Is(err, models.ErrSongNotFound) {
+			t.Errorf("RestoreSong(%d) of a song not in the trash: %v, want ErrSongNotFound", id, err)
+		}
+	}
+
+	if purged, err := repo.PurgeTrash(ctx, time.Hour); err != nil || purged != 0 {
+		t.Errorf("PurgeTrash(1h) = %d, %v, want 0", purged, err)
+	}
+
+	// SQLite keeps deleted_at to the second.
+	time.Sleep(1100 * time.Millisecond)
+
+	if purged, err := repo.PurgeTrash(ctx, 0); err != nil || purged != 1 {
+		t.Errorf("PurgeTrash(0) = %d, %v, want 1", purged, err)
+	}
+	if _, total, _ = repo.GetTrash(ctx, 1, 10); total != 0 {
+		t.Errorf("trash after purge has %d songs, want 0", total)
+	}
+	if err = repo.RestoreSong(ctx, replacement); !errors.Is(err, models.ErrSongNotFound) {
+		t.Errorf("RestoreSong of a purged song: %v, want ErrSongNotFound", err)
+	}
+}
+
+func testEdit(t *testing.T, repo song.Repo) {
+	ctx := context.Background()
+	id := mustAdd(t, repo, newSong("Muse", "Uprising", "a\n\nb"))
+
+	newName := "Resistance"
+	newLink := "https://example.com/resistance"
+	version, err := repo.Edit(ctx, id, &models.EditSongRequest{
+		SongName: &newName,
+		Link:     &newLink,
+		Verse:    &models.VerseToUpdate{Index: 2, Text: "changed"},
+	}, 1)
+	if err != nil || version != 2 {
+		t.Fatalf("Edit = %d, %v, want version 2", version, err)
+	}
+
+	songs, _ := mustGetSongs(t, repo, &models.SongFilter{}, 1, 10)
+	if len(songs) != 1 {
+		t.Fatalf("got %d songs, want 1", len(songs))
+	}
+
+	got := songs[0]
+	if got.GroupName != "Muse" || got.SongName != newName || got.Link != newLink || got.ReleaseDate != "2006-07-16" || got.Version != 2 {
+		t.Errorf("edited song = %+v", got)
+	}
+
+	verses, _ := mustGetVerses(t, repo, id, 1, 10)
+	if len(verses) != 2 || verses[0].Text != "a" || verses[1].Text != "changed" {
+		t.Errorf("verses after edit = %+v", verses)
+	}
+
+	if version, err = repo.Edit(ctx, id, &models.EditSongRequest{}, 0); err != nil || version != 2 {
+		t.Errorf("empty Edit = %d, %v, want version 2", version, err)
+	}
+}
+
+func testEditVersion(t *testing.T, repo song.Repo) {
+	ctx := context.Background()
+	id := mustAdd(t, repo, newSong("Muse", "Uprising", "a\n\nb"))
+
+	verse := &models.EditSongRequest{Verse: &models.VerseToUpdate{Index: 1, Text: "changed"}}
+	if version, err := repo.Edit(ctx, id, verse, 1); err != nil || version != 2 {
+		t.Fatalf("Edit of a verse = %d, %v, want version 2", version, err)
+	}
+
+	newName := "Resistance"
+	if _, err := repo.Edit(ctx, id, &models.EditSongRequest{SongName: &newName}, 1); !errors.Is(err, models.ErrVersionMismatch) {
+		t.Errorf("Edit based on version 1: %v, want ErrVersionMismatch", err)
+	}
+
+	if _, err := repo.AddVerse(ctx, id, 0, "c"); err != nil {
+		t.Fatalf("AddVerse: %v", err)
+	}
+	got, err := repo.GetSong(ctx, id)
+	if err != nil || got.Version != 3 || got.SongName != "Uprising" {
+		t.Errorf("GetSong after AddVerse = %+v, %v, want version 3", got, err)
+	}
+}
+
+func testEditMissingVerse(t *testing.T, repo song.Repo) {
+	id := mustAdd(t, repo, newSong("Muse", "Uprising", "a"))
+
+	newName := "Resistance"
+	_, err := repo.Edit(context.Background(), id, &models.EditSongRequest{
+		SongName: &newName,
+		Verse:    &models.VerseToUpdate{Index: 5, Text: "x"},
+	}, 0)
+	if !errors.Is(err, models.ErrVerseNotFound) || !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("Edit of a missing verse: %v, want ErrVerseNotFound", err)
+	}
+
+	songs, _ := mustGetSongs(t, repo, &models.SongFilter{}, 1, 10)
+	if len(songs) != 1 || songs[0].SongName != "Uprising" {
+		t.Errorf("song after failed edit = %+v, want it unchanged", songs)
+	}
+}
+
+func testManageVerses(t *testing.T, repo song.Repo) {
+	ctx := context.Background()
+	id := mustAdd(t, repo, newSong("Muse", "Uprising", "a\n\nb"))
+
+	check := func(want ...string) {
+		t.Helper()
+
+		verses, total := mustGetVerses(t, repo, id, 1, 10)
+		got := make([]string, 0, len(verses))
+		for i, v := range verses {
+			if v.Index != i+1 {
+				t.Errorf("verse %q has index %d, want %d", v.Text, v.Index, i+1)
+			}
+			got = append(got, v.Text)
+		}
+		if total != len(want) || strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("verses = %v (total %d), want %v", got, total, want)
+		}
+	}
+
+	index, err := repo.AddVerse(ctx, id, 0, "c")
+	if err != nil || index != 3 {
+		t.Fatalf("AddVerse append = %d, %v, want 3", index, err)
+	}
+	index, err = repo.AddVerse(ctx, id, 1, "first")
+	if err != nil || index != 1 {
+		t.Fatalf("AddVerse at 1 = %d, %v, want 1", index, err)
+	}
+	check("first", "a", "b", "c")
+
+	if _, err = repo.AddVerse(ctx, id, 6, "x"); !errors.Is(err, models.ErrVerseNotFound) {
+		t.Errorf("AddVerse past the end: %v, want ErrVerseNotFound", err)
+	}
+
+	if err = repo.DeleteVerse(ctx, id, 2); err != nil {
+		t.Fatalf("DeleteVerse: %v", err)
+	}
+	check("first", "b", "c")
+
+	if err = repo.DeleteVerse(ctx, id, 4); !errors.Is(err, models.ErrVerseNotFound) {
+		t.Errorf("DeleteVerse of a missing verse: %v, want ErrVerseNotFound", err)
+	}
+
+	if err = repo.ReorderVerses(ctx, id, []int{3, 1, 2}); err != nil {
+		t.Fatalf("ReorderVerses: %v", err)
+	}
+	check("c", "first", "b")
+
+	for _, order := range [][]int{{1, 2}, {1, 1, 2}, {1, 2, 4}} {
+		if err = repo.ReorderVerses(ctx, id, order); !errors.Is(err, models.ErrInvalidVerseOrder) {
+			t.Errorf("ReorderVerses(%v): %v, want ErrInvalidVerseOrder", order, err)
+		}
+	}
+	check("c", "first", "b")
+
+	if _, err = repo.AddVerse(ctx, id+1, 0, "x"); !errors.Is(err, models.ErrSongNotFound) {
+		t.Errorf("AddVerse to a missing song: %v, want ErrSongNotFound", err)
+	}
+}
+
+func testReplaceText(t *testing.T, repo song.Repo) {
+	ctx := context.Background()
+	id := mustAdd(t, repo, newSong("Muse", "Uprising", "a\n\nb\n\nc"))
+	other := mustAdd(t, repo, newSong("Muse", "Resistance", "x\n\ny"))
+
+	count, err := repo.ReplaceText(ctx, id, "first\nline\n\nsecond")
+	if err != nil || count != 2 {
+		t.Fatalf("ReplaceText = %d, %v, want 2", count, err)
+	}
+
+	verses, total := mustGetVerses(t, repo, id, 1, 10)
+	if total != 2 || len(verses) != 2 ||
+		verses[0].Index != 1 || verses[0].Text != "first\nline" ||
+		verses[1].Index != 2 || verses[1].Text != "second" {
+		t.Errorf("verses after ReplaceText = %+v", verses)
+	}
+
+	if _, total = mustGetVerses(t, repo, other, 1, 10); total != 2 {
+		t.Errorf("other song has %d verses, want 2", total)
+	}
+
+	if _, err = repo.ReplaceText(ctx, other+1, "x"); !errors.Is(err, models.ErrSongNotFound) {
+		t.Errorf("ReplaceText of a missing song: %v, want ErrSongNotFound", err)
+	}
+}
+
+func testRevisions(t *testing.T, repo song.Repo) {
+	ctx := models.WithActor(context.Background(), "alice")
+	id, err := repo.Add(ctx, newSong("Muse", "Uprising", "a\n\nb"))
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	newName := "Resistance"
+	if _, err = repo.Edit(ctx, id, &models.EditSongRequest{SongName: &newName}, 0); err != nil {
+		t.Fatalf("Edit: %v", err)
+	}
+	if _, err = repo.AddVerse(ctx, id, 0, "c"); err != nil {
+		t.Fatalf("AddVerse: %v", err)
+	}
+
+	revisions, total, err := repo.GetRevisions(ctx, id, 1, 10)
+	if err != nil {
+		t.Fatalf("GetRevisions: %v", err)
+	}
+	var actions []string
+	for _, rev := range revisions {
+		actions = append(actions, rev.Action)
+		if rev.Actor != "alice" || rev.CreatedAt == "" {
+			t.Errorf("revision %d = %+v, want actor alice", rev.Revision, rev)
+		}
+	}
+	if total != 3 || !slices.Equal(actions, []string{models.RevisionAddVerse, models.RevisionEdit, models.RevisionCreate}) {
+		t.Fatalf("GetRevisions = %v (total %d)", actions, total)
+	}
+	if created := revisions[2]; created.Revision != 1 || created.Before != nil ||
+		created.After == nil || created.After.GroupName != "Muse" || !slices.Equal(created.After.Verses, []string{"a", "b"}) {
+		t.Errorf("create revision = %+v", created)
+	}
+	if edit := revisions[1]; edit.Before == nil || edit.Before.SongName != "Uprising" ||
+		edit.After == nil || edit.After.SongName != "Resistance" {
+		t.Errorf("edit revision = %+v", edit)
+	}
+
+	rolledBack, err := repo.RestoreRevision(ctx, id, 1)
+	if err != nil || rolledBack != 4 {
+		t.Fatalf("RestoreRevision(1) = %d, %v, want 4", rolledBack, err)
+	}
+	if got, err := repo.GetSong(ctx, id); err != nil || got.SongName != "Uprising" || got.Text != "a\n\nb" {
+		t.Errorf("GetSong after RestoreRevision = %+v, %v", got, err)
+	}
+	if rev, err := repo.GetRevision(ctx, id, 4); err != nil || rev.Action != models.RevisionRollback ||
+		rev.Before == nil || !slices.Equal(rev.Before.Verses, []string{"a", "b", "c"}) {
+		t.Errorf("GetRevision(4) = %+v, %v", rev, err)
+	}
+
+	mustAdd(t, repo, newSong("Muse", "Resistance", "x"))
+	if _, err = repo.RestoreRevision(ctx, id, 2); !errors.Is(err, models.ErrSongExists) {
+		t.Errorf("RestoreRevision onto a taken name: %v, want ErrSongExists", err)
+	}
+	if _, err = repo.RestoreRevision(ctx, id, 99); !errors.Is(err, models.ErrRevisionNotFound) {
+		t.Errorf("RestoreRevision(99): %v, want ErrRevisionNotFound", err)
+	}
+
+	if err = repo.Delete(ctx, id); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err = repo.RestoreSong(ctx, id); err != nil {
+		t.Fatalf("RestoreSong: %v", err)
+	}
+	revisions, total, err = repo.GetRevisions(ctx, id, 1, 2)
+	if err != nil || total != 6 || len(revisions) != 2 ||
+		revisions[0].Action != models.RevisionRestore || revisions[1].Action != models.RevisionDelete || revisions[1].After != nil {
+		t.Errorf("GetRevisions after Delete and RestoreSong = %+v (total %d), %v", revisions, total, err)
+	}
+}
+
+func testGetSong(t *testing.T, repo song.Repo) {
+	ctx := context.Background()
+	id := mustAdd(t, repo, newSong("Muse", "Uprising", "a\nb\n\nc"))
+
+	if _, err := repo.AddVerse(ctx, id, 1, "first"); err != nil {
+		t.Fatalf("AddVerse: %v", err)
+	}
+
+	got, err := repo.GetSong(ctx, id)
+	if err != nil {
+		t.Fatalf("GetSong: %v", err)
+	}
+	if got.ID != id || got.GroupID == 0 || got.GroupName != "Muse" || got.SongName != "Uprising" ||
+		got.ReleaseDate != "2006-07-16" || got.Link != "https://example.com/Uprising" ||
+		got.CreatedAt == "" || got.UpdatedAt == "" {
+		t.Errorf("GetSong = %+v", got)
+	}
+	if want := "first\n\na\nb\n\nc"; got.Text != want {
+		t.Errorf("GetSong text = %q, want %q", got.Text, want)
+	}
+
+	if _, err = repo.GetSong(ctx, id+1); !errors.Is(err, models.ErrSongNotFound) {
+		t.Errorf("GetSong of a missing song: %v, want ErrSongNotFound", err)
+	}
+}
+
+func testGetSongsFilters(t *testing.T, repo song.Repo) {
+	ctx := context.Background()
+
+	add := func(group, name string, released models.Date) int {
+		s := newSong(group, name, name)
+		s.ReleaseDate = released
+		return mustAdd(t, repo, s)
+	}
+
+	a := add("Muse", "Uprising", "2009-09-14")
+	b := add("Muse", "Resistance", "2010-02-22")
+	c := add("Queen", "Uprising", "1975-10-31")
+	d := add("Queen", "Bohemian Rhapsody", "1975-10-31")
+	e := add("Кино", "Группа крови", "1988-01-05")
+	f := add("100%_Pure", "Unknown", "")
+
+	date := func(value string) *time.Time {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &parsed
+	}
+
+	hourAgo := time.Now().Add(-time.Hour)
+	hourLater := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		filter  models.SongFilter
+		wantIDs []int
+	}{
+		{name: "no filter", wantIDs: []int{a, b, c, d, e, f}},
+		{name: "group", filter: models.SongFilter{Group: "Muse"}, wantIDs: []int{a, b}},
+		{name: "group substring case-insensitive", filter: models.SongFilter{Group: "uEE"}, wantIDs: []int{c, d}},
+		{name: "song substring", filter: models.SongFilter{Song: "rising"}, wantIDs: []int{a, c}},
+		{name: "cyrillic case-insensitive", filter: models.SongFilter{Song: "КРОВИ"}, wantIDs: []int{e}},
+		{name: "group and song", filter: models.SongFilter{Group: "queen", Song: "uprising"}, wantIDs: []int{c}},
+		{name: "like wildcards are literal", filter: models.SongFilter{Group: "%_"}, wantIDs: []int{f}},
+		{name: "no match", filter: models.SongFilter{Group: "Nobody"}, wantIDs: []int{}},
+		{name: "released after", filter: models.SongFilter{ReleasedAfter: date("2009-09-14")}, wantIDs: []int{a, b}},
+		{name: "released before", filter: models.SongFilter{ReleasedBefore: date("1988-01-05")}, wantIDs: []int{c, d, e}},
+		{
+			name:    "released between",
+			filter:  models.SongFilter{ReleasedAfter: date("1980-01-01"), ReleasedBefore: date("2009-12-31")},
+			wantIDs: []int{a, e},
+		},
+		{name: "created after", filter: models.SongFilter{CreatedAfter: &hourAgo}, wantIDs: []int{a, b, c, d, e, f}},
+		{name: "created after future", filter: models.SongFilter{CreatedAfter: &hourLater}, wantIDs: []int{}},
+		{name: "created before past", filter: models.SongFilter{CreatedBefore: &hourAgo}, wantIDs: []int{}},
+		{
+			name:    "created between",
+			filter:  models.SongFilter{Group: "Muse", CreatedAfter: &hourAgo, CreatedBefore: &hourLater},
+			wantIDs: []int{a, b},
+		},
+		{name: "sort by id desc", filter: models.SongFilter{SortDesc: true}, wantIDs: []int{f, e, d, c, b, a}},
+		{name: "sort by song name", filter: models.SongFilter{Group: "Queen", SortBy: "song_name"}, wantIDs: []int{d, c}},
+		{
+			name:    "sort by release date",
+			filter:  models.SongFilter{SortBy: "release_date"},
+			wantIDs: []int{f, c, d, e, a, b},
+		},
+		{
+			name:    "sort by release date desc",
+			filter:  models.SongFilter{SortBy: "release_date", SortDesc: true},
+			wantIDs: []int{b, a, e, d, c, f},
+		},
+		{name: "sort by created at", filter: models.SongFilter{Group: "Muse", SortBy: "created_at"}, wantIDs: []int{a, b}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			songs, total := mustGetSongs(t, repo, &tt.filter, 1, 10)
+			if total != len(tt.wantIDs) {
+				t.Errorf("total = %d, want %d", total, len(tt.wantIDs))
+			}
+			if ids := songIDs(songs); !equalInts(ids, tt.wantIDs) {
+				t.Errorf("ids = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+
+	if _, _, err := repo.GetSongs(ctx, &models.SongFilter{SortBy: "text; DROP TABLE songs"}, &models.Page{Limit: 10}); err == nil {
+		t.Error("GetSongs with an unknown sort column succeeded, want error")
+	}
+}
+
+func testGetSongsPagination(t *testing.T, repo song.Repo) {
+	var ids []int
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		ids = append(ids, mustAdd(t, repo, newSong("Muse", name, name)))
+	}
+
+	tests := []struct {
+		page    int
+		limit   int
+		wantIDs []int
+	}{
+		{page: 1, limit: 2, wantIDs: ids[0:2]},
+		{page: 2, limit: 2, wantIDs: ids[2:4]},
+		{page: 3, limit: 2, wantIDs: ids[4:5]},
+		{page: 4, limit: 2, wantIDs: []int{}},
+	}
+
+	for _, tt := range tests {
+		songs, total := mustGetSongs(t, repo, &models.SongFilter{}, tt.page, tt.limit)
+		if total != len(ids) {
+			t.Errorf("page %d: total = %d, want %d", tt.page, total, len(ids))
+		}
+		if got := songIDs(songs); !equalInts(got, tt.wantIDs) {
+			t.Errorf("page %d: ids = %v, want %v", tt.page, got, tt.wantIDs)
+		}
+	}
+}
+
+func testGetSongsKeyset(t *testing.T, repo song.Repo) {
+	ctx := context.Background()
+
+	releases := []models.Date{"2001-01-01", "1999-01-01", "2001-01-01", "2010-06-15", "", "1999-01-01", "2005-12-30"}
+	for i, released := range releases {
+		s := newSong([]string{"Muse", "Queen", "ABBA"}[i%3], fmt.Sprintf("song %d", i%4), "text")
+		s.ReleaseDate = released
+		mustAdd(t, repo, s)
+	}
+
+	filters := []models.SongFilter{
+		{},
+		{SortDesc: true},
+		{SortBy: "release_date"},
+		{SortBy: "release_date", SortDesc: true},
+		{SortBy: "group_name"},
+		{SortBy: "song_name", SortDesc: true},
+		{SortBy: "created_at"},
+		{SortBy: "updated_at", SortDesc: true},
+		{Group: "u", SortBy: "release_date"},
+	}
+
+	for _, filter := range filters {
+		t.Run(fmt.Sprintf("%s desc=%v group=%q", filter.SortBy, filter.SortDesc, filter.Group), func(t *testing.T) {
+			all, total := mustGetSongs(t, repo, &filter, 1, 100)
+			want := songIDs(all)
+
+			// Walk forward two rows at a time, then back again from the end.
+			var forward []int
+			var cursor *models.Cursor
+			for {
+				songs, count, err := repo.GetSongs(ctx, &filter, &models.Page{Limit: 2, Cursor: cursor})
+				if err != nil {
+					t.Fatalf("GetSongs: %v", err)
+				}
+				if count != total {
+					t.Errorf("total with cursor = %d, want %d", count, total)
+				}
+				if len(songs) == 0 {
+					break
+				}
+				forward = append(forward, songIDs(songs)...)
+				last := songs[len(songs)-1]
+				cursor = &models.Cursor{Key: last.SortValue(filter.SortBy), ID: last.ID}
+			}
+
+			if !equalInts(forward, want) {
+				t.Fatalf("forward walk = %v, want %v", forward, want)
+			}
+
+			var backward []int
+			cursor = &models.Cursor{Key: all[len(all)-1].SortValue(filter.SortBy), ID: all[len(all)-1].ID, Backward: true}
+			for {
+				songs, _, err := repo.GetSongs(ctx, &filter, &models.Page{Limit: 2, Cursor: cursor})
+				if err != nil {
+					t.Fatalf("GetSongs: %v", err)
+				}
+				if len(songs) == 0 {
+					break
+				}
+				backward = append(songIDs(songs), backward...)
+				first := songs[0]
+				cursor = &models.Cursor{Key: first.SortValue(filter.SortBy), ID: first.ID, Backward: true}
+			}
+
+			if !equalInts(backward, want[:len(want)-1]) {
+				t.Fatalf("backward walk = %v, want %v", backward, want[:len(want)-1])
+			}
+		})
+	}
+
+	// Rows inserted behind the cursor must not shift the next page.
+	first, _ := mustGetSongs(t, repo, &models.SongFilter{}, 1, 3)
+	mustAdd(t, repo, newSong("AAA", "inserted", "text"))
+	last := first[len(first)-1]
+	next, _, err := repo.GetSongs(ctx, &models.SongFilter{}, &models.Page{Limit: 3, Cursor: &models.Cursor{ID: last.ID}})
+	if err != nil {
+		t.Fatalf("GetSongs: %v", err)
+	}
+	if len(next) == 0 || next[0].ID != last.ID+1 {
+		t.Errorf("page after cursor %d starts with %v", last.ID, songIDs(next))
+	}
+}
+
+func testGetSongVerses(t *testing.T, repo song.Repo) {
+	id := mustAdd(t, repo, newSong("Muse", "Uprising", "1\n\n2\n\n3\n\n4\n\n5"))
+	mustAdd(t, repo, newSong("Muse", "Resistance", "x\n\ny"))
+
+	tests := []struct {
+		page      int
+		limit     int
+		wantTexts []string
+	}{
+		{page: 1, limit: 2, wantTexts: []string{"1", "2"}},
+		{page: 2, limit: 2, wantTexts: []string{"3", "4"}},
+		{page: 3, limit: 2, wantTexts: []string{"5"}},
+		{page: 4, limit: 2, wantTexts: nil},
+	}
+
+	for _, tt := range tests {
+		verses, total := mustGetVerses(t, repo, id, tt.page, tt.limit)
+		if total != 5 {
+			t.Errorf("page %d: total = %d, want 5", tt.page, total)
+		}
+		if len(verses) != len(tt.wantTexts) {
+			t.Errorf("page %d: got %d verses, want %d", tt.page, len(verses), len(tt.wantTexts))
+			continue
+		}
+		for i, verse := range verses {
+			if verse.Text != tt.wantTexts[i] {
+				t.Errorf("page %d: verse %d text = %q, want %q", tt.page, i, verse.Text, tt.wantTexts[i])
+			}
+		}
+	}
+}
+
+func testGetSongVersesKeyset(t *testing.T, repo song.Repo) {
+	ctx := context.Background()
+	id := mustAdd(t, repo, newSong("Muse", "Uprising", "1\n\n2\n\n3\n\n4\n\n5"))
+
+	var texts []string
+	var cursor *models.Cursor
+	for {
+		verses, total, err := repo.GetSongVerses(ctx, id, &models.Page{Limit: 2, Cursor: cursor})
+		if err != nil {
+			t.Fatalf("GetSongVerses: %v", err)
+		}
+		if total != 5 {
+			t.Errorf("total = %d, want 5", total)
+		}
+		if len(verses) == 0 {
+			break
+		}
+		for _, verse := range verses {
+			texts = append(texts, verse.Text)
+		}
+		last := verses[len(verses)-1]
+		cursor = &models.Cursor{Key: strconv.Itoa(last.Index), ID: last.Id}
+	}
+
+	if got := strings.Join(texts, ","); got != "1,2,3,4,5" {
+		t.Errorf("forward walk = %s, want 1,2,3,4,5", got)
+	}
+
+	all, _ := mustGetVerses(t, repo, id, 1, 10)
+	last := all[len(all)-1]
+	verses, _, err := repo.GetSongVerses(ctx, id, &models.Page{Limit: 2, Cursor: &models.Cursor{Key: strconv.Itoa(last.Index), ID: last.Id, Backward: true}})
+	if err != nil {
+		t.Fatalf("GetSongVerses: %v", err)
+	}
+	if len(verses) != 2 || verses[0].Text != "3" || verses[1].Text != "4" {
+		t.Errorf("page before the last verse = %+v, want verses 3 and 4", verses)
+	}
+}
+
+func testSearchVerses(t *testing.T, repo song.Repo) {
+	ctx := context.Background()
+	muse := mustAdd(t, repo, newSong("Muse", "Uprising", "They will not force us\n\nThey will stop degrading us\n\nWe will be victorious"))
+	queen := mustAdd(t, repo, newSong("Queen", "We Will Rock You", "Buddy you're a boy\n\nWe will, we will rock you"))
+	doomed := mustAdd(t, repo, newSong("Nobody", "Gone", "we will vanish"))
+
+	if err := repo.Delete(ctx, doomed); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	type hit struct{ songID, verse int }
+
+	tests := []struct {
+		query string
+		want  []hit
+	}{
+		{query: "victorious", want: []hit{{muse, 3}}},
+		{query: "VICTORIOUS", want: []hit{{muse, 3}}},
+		{query: "we will", want: []hit{{muse, 3}, {queen, 2}}},
+		{query: "will rock", want: []hit{{queen, 2}}},
+		{query: "force rock", want: nil},
+		{query: "vanish", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			results, total, err := repo.SearchVerses(ctx, tt.query, 1, 10)
+			if err != nil {
+				t.Fatalf("SearchVerses: %v", err)
+			}
+			if total != len(tt.want) || len(results) != len(tt.want) {
+				t.Fatalf("got %d results (total %d), want %d: %+v", len(results), total, len(tt.want), results)
+			}
+
+			got := make(map[hit]bool)
+			for _, r := range results {
+				got[hit{r.SongID, r.VerseIndex}] = true
+				if !strings.Contains(r.Snippet, "<b>") {
+					t.Errorf("snippet %q has no highlighted match", r.Snippet)
+				}
+				if r.SongName == "" || r.GroupName == "" {
+					t.Errorf("result %+v lacks song metadata", r)
+				}
+			}
+			for _, w := range tt.want {
+				if !got[w] {
+					t.Errorf("missing song %d verse %d in %+v", w.songID, w.verse, results)
+				}
+			}
+		})
+	}
+
+	first, total, err := repo.SearchVerses(ctx, "we will", 1, 1)
+	if err != nil {
+		t.Fatalf("SearchVerses: %v", err)
+	}
+	second, _, err := repo.SearchVerses(ctx, "we will", 2, 1)
+	if err != nil {
+		t.Fatalf("SearchVerses: %v", err)
+	}
+	if total != 2 || len(first) != 1 || len(second) != 1 || first[0] == second[0] {
+		t.Errorf("paged search returned %+v and %+v (total %d)", first, second, total)
+	}
+}
+
+func testExists(t *testing.T, repo song.Repo) {
+	ctx := context.Background()
+	id := mustAdd(t, repo, newSong("Muse", "Uprising", "a\n\nb"))
+
+	tests := []struct {
+		name  string
+		check func() (bool, error)
+		want  bool
+	}{
+		{"song", func() (bool, error) { return repo.SongExists(ctx, id) }, true},
+		{"missing song", func() (bool, error) { return repo.SongExists(ctx, id+100) }, false},
+		{"verse", func() (bool, error) { return repo.VerseExists(ctx, id, 2) }, true},
+		{"missing verse", func() (bool, error) { return repo.VerseExists(ctx, id, 3) }, false},
+		{"verse of missing song", func() (bool, error) { return repo.VerseExists(ctx, id+100, 1) }, false},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.check()
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+// RunUserRepo runs the auth.Repo contract: unique usernames and refresh
+// token rotation with reuse detection.
+func RunUserRepo(t *testing.T, newRepos ReposFactory) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repos *Repos)
+	}{
+		{"Users", testUsers},
+		{"RotateRefreshToken", testRotateRefreshToken},
+		{"RefreshTokenReuse", testRefreshTokenReuse},
+		{"RefreshTokenExpired", testRefreshTokenExpired},
+		{"RevokeRefreshToken", testRevokeRefreshToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepos(t))
+		})
+	}
+}
+
+func mustAddUser(t *testing.T, repos *Repos, username string) int {
+	t.Helper()
+
+	id, err := repos.Users.AddUser(context.Background(), username, "hash-of-"+username)
+	if err != nil {
+		t.Fatalf("AddUser(%q): %v", username, err)
+	}
+	return id
+}
+
+func mustAddRefreshToken(t *testing.T, repos *Repos, userID int, hash string, expiresAt time.Time) {
+	t.Helper()
+
+	token := &models.RefreshToken{UserID: userID, TokenHash: hash, ExpiresAt: expiresAt}
+	if err := repos.Users.AddRefreshToken(context.Background(), token); err != nil {
+		t.Fatalf("AddRefreshToken(%q): %v", hash, err)
+	}
+}
+
+func testUsers(t *testing.T, repos *Repos) {
+	ctx := context.Background()
+
+	id := mustAddUser(t, repos, "alice")
+
+	if _, err := repos.Users.AddUser(ctx, "alice", "other"); !errors.Is(err, models.ErrUserExists) {
+		t.Fatalf("AddUser duplicate error = %v, want %v", err, models.ErrUserExists)
+	}
+
+	user, err := repos.Users.GetUserByName(ctx, "alice")
+	if err != nil {
+		t.Fatalf("GetUserByName: %v", err)
+	}
+	if user == nil || user.ID != id || user.PasswordHash != "hash-of-alice" {
+		t.Fatalf("GetUserByName = %+v, want id %d with its password hash", user, id)
+	}
+
+	user, err = repos.Users.GetUserByName(ctx, "bob")
+	if err != nil || user != nil {
+		t.Fatalf("GetUserByName(missing) = %+v, %v, want nil, nil", user, err)
+	}
+}
+
+func testRotateRefreshToken(t *testing.T, repos *Repos) {
+	ctx := context.Background()
+	now := time.Now()
+
+	id := mustAddUser(t, repos, "alice")
+	mustAddRefreshToken(t, repos, id, "first", now.Add(time.Hour))
+
+	next := &models.RefreshToken{TokenHash: "second", ExpiresAt: now.Add(time.Hour)}
+	user, err := repos.Users.RotateRefreshToken(ctx, "first", next, now)
+	if err != nil {
+		t.Fatalf("RotateRefreshToken: %v", err)
+	}
+	if user.ID != id || user.Username != "alice" {
+		t.Fatalf("RotateRefreshToken user = %+v, want alice", user)
+	}
+
+	third := &models.RefreshToken{TokenHash: "third", ExpiresAt: now.Add(time.Hour)}
+	if _, err = repos.Users.RotateRefreshToken(ctx, "second", third, now); err != nil {
+		t.Fatalf("RotateRefreshToken(successor): %v", err)
+	}
+
+	_, err = repos.Users.RotateRefreshToken(ctx, "missing", &models.RefreshToken{TokenHash: "x", ExpiresAt: now}, now)
+	if !errors.Is(err, models.ErrInvalidRefreshToken) {
+		t.Fatalf("RotateRefreshToken(missing) error = %v, want %v", err, models.ErrInvalidRefreshToken)
+	}
+}
+
+func testRefreshTokenReuse(t *testing.T, repos *Repos) {
+	ctx := context.Background()
+	now := time.Now()
+
+	id := mustAddUser(t, repos, "alice")
+	mustAddRefreshToken(t, repos, id, "first", now.Add(time.Hour))
+	mustAddRefreshToken(t, repos, id, "other-device", now.Add(time.Hour))
+
+	other := mustAddUser(t, repos, "bob")
+	mustAddRefreshToken(t, repos, other, "bob", now.Add(time.Hour))
+
+	next := &models.RefreshToken{TokenHash: "second", ExpiresAt: now.Add(time.Hour)}
+	if _, err := repos.Users.RotateRefreshToken(ctx, "first", next, now); err != nil {
+		t.Fatalf("RotateRefreshToken: %v", err)
+	}
+
+	retry := &models.RefreshToken{TokenHash: "retry", ExpiresAt: now.Add(time.Hour)}
+	if _, err := repos.Users.RotateRefreshToken(ctx, "first", retry, now); !errors.Is(err, models.ErrRefreshTokenReused) {
+		t.Fatalf("RotateRefreshToken(reused) error = %v, want %v", err, models.ErrRefreshTokenReused)
+	}
+
+	// Every token of the user is gone, including the successor.
+	for _, hash := range []string{"second", "other-device"} {
+		next := &models.RefreshToken{TokenHash: hash + "-next", ExpiresAt: now.Add(time.Hour)}
+		if _, err := repos.Users.RotateRefreshToken(ctx, hash, next, now); !errors.Is(err, models.ErrRefreshTokenReused) {
+			t.Fatalf("RotateRefreshToken(%q) error = %v, want %v", hash, err, models.ErrRefreshTokenReused)
+		}
+	}
+
+	next = &models.RefreshToken{TokenHash: "bob-next", ExpiresAt: now.Add(time.Hour)}
+	if _, err := repos.Users.RotateRefreshToken(ctx, "bob", next, now); err != nil {
+		t.Fatalf("RotateRefreshToken(other user): %v", err)
+	}
+}
+
+func testRefreshTokenExpired(t *testing.T, repos *Repos) {
+	ctx := context.Background()
+	now := time.Now()
+
+	id := mustAddUser(t, repos, "alice")
+	mustAddRefreshToken(t, repos, id, "first", now.Add(-time.Minute))
+
+	next := &models.RefreshToken{TokenHash: "second", ExpiresAt: now.Add(time.Hour)}
+	if _, err := repos.Users.RotateRefreshToken(ctx, "first", next, now); !errors.Is(err, models.ErrInvalidRefreshToken) {
+		t.Fatalf("RotateRefreshToken(expired) error = %v, want %v", err, models.ErrInvalidRefreshToken)
+	}
+}
+
+func testRevokeRefreshToken(t *testing.T, repos *Repos) {
+	ctx := context.Background()
+	now := time.Now()
+
+	id := mustAddUser(t, repos, "alice")
+	mustAddRefreshToken(t, repos, id, "first", now.Add(time.Hour))
+
+	if err := repos.Users.RevokeRefreshToken(ctx, "first", now); err != nil {
+		t.Fatalf("RevokeRefreshToken: %v", err)
+	}
+	if err := repos.Users.RevokeRefreshToken(ctx, "missing", now); err != nil {
+		t.Fatalf("RevokeRefreshToken(missing): %v", err)
+	}
+
+	next := &models.RefreshToken{TokenHash: "second", ExpiresAt: now.Add(time.Hour)}
+	if _, err := repos.Users.RotateRefreshToken(ctx, "first", next, now); err == nil {
+		t.Fatal("RotateRefreshToken(revoked) succeeded")
+	}
+}
@@ -0,0 +1,243 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+type AlbumRepository struct {
+	db *sqlx.DB
+}
+
+func NewAlbumRepository(db *sqlx.DB) *AlbumRepository {
+	return &AlbumRepository{db: db}
+}
+
+func (m *AlbumRepository) Add(ctx context.Context, input *models.NewAlbumRequest) (int, error) {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `INSERT INTO albums(group_id, title, release_date, cover_link) VALUES (?, ?, ?, ?)`
+	res, err := tx.ExecContext(ctx, query,
+		input.GroupID,
+		input.Title,
+		input.ReleaseDate,
+		input.CoverLink,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		switch {
+		case isSQLiteError(err, sqlite3.SQLITE_CONSTRAINT_UNIQUE):
+			return 0, models.ErrAlbumExists
+		case isSQLiteError(err, sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY):
+			return 0, models.ErrGroupNotFound
+		default:
+			return 0, err
+		}
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	if err = insertTracks(ctx, tx, int(id), input.Tracks); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+func (m *AlbumRepository) Delete(ctx context.Context, id int) error {
+	query := `DELETE 
+			  FROM albums 
+			  WHERE id = ?`
+	_, err := m.db.ExecContext(ctx, query, id)
+	return err
+}
+
+func (m *AlbumRepository) Edit(ctx context.Context, id int, input *models.EditAlbumRequest) error {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if input.Title != nil {
+		args = append(args, *input.Title)
+		conditions = append(conditions, "title = ?")
+	}
+
+	if input.ReleaseDate != nil {
+		args = append(args, *input.ReleaseDate)
+		conditions = append(conditions, "release_date = ?")
+	}
+
+	if input.CoverLink != nil {
+		args = append(args, *input.CoverLink)
+		conditions = append(conditions, "cover_link = ?")
+	}
+
+	if len(args) == 0 && input.Tracks == nil {
+		return nil
+	}
+
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	conditions = append(conditions, "updated_at = CURRENT_TIMESTAMP")
+	query := `UPDATE albums SET` + " " + strings.Join(conditions, ", ") + " WHERE id = ?"
+
+	if _, err = tx.ExecContext(ctx, query, append(args, id)...); err != nil {
+		_ = tx.Rollback()
+		if isSQLiteError(err, sqlite3.SQLITE_CONSTRAINT_UNIQUE) {
+			return models.ErrAlbumExists
+		}
+		return fmt.Errorf("failed to execute update query for album with id - %d: %w", id, err)
+	}
+
+	if input.Tracks != nil {
+		if _, err = tx.ExecContext(ctx, `DELETE FROM album_tracks WHERE album_id = ?`, id); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+
+		if err = insertTracks(ctx, tx, id, *input.Tracks); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (m *AlbumRepository) GetAlbum(ctx context.Context, id int) (*models.Album, error) {
+	var album models.Album
+
+	query := `SELECT a.id, a.group_id, g.name AS group_name, a.title, a.release_date,
+                     a.cover_link, a.created_at, a.updated_at
+              FROM albums AS a
+              JOIN groups AS g ON g.id = a.group_id
+              WHERE a.id = ?`
+
+	err := m.db.GetContext(ctx, &album, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tracksQuery := `SELECT t.track_number, t.song_id, s.song_name
+                    FROM album_tracks AS t
+                    JOIN songs AS s ON s.id = t.song_id
+                    WHERE t.album_id = ? AND s.deleted_at IS NULL
+                    ORDER BY t.track_number`
+
+	err = m.db.SelectContext(ctx, &album.Tracks, tracksQuery, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &album, nil
+}
+
+func (m *AlbumRepository) GetAlbums(ctx context.Context, filter *models.AlbumFilter, page, limit int) ([]models.Album, int, error) {
+	var (
+		albums     []models.Album
+		conditions []string
+		args       []interface{}
+		totalCount int
+	)
+
+	if filter.GroupID != 0 {
+		args = append(args, filter.GroupID)
+		conditions = append(conditions, "a.group_id = ?")
+	}
+
+	if filter.Title != "" {
+		args = append(args, escapeLike(filter.Title))
+		conditions = append(conditions, `casefold(a.title) LIKE '%' || casefold(?) || '%' ESCAPE '\'`)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+
+	query := `SELECT a.id, a.group_id, g.name AS group_name, a.title, a.release_date,
+                     a.cover_link, a.created_at, a.updated_at
+              FROM albums AS a
+              JOIN groups AS g ON g.id = a.group_id` + where + ` ORDER BY a.id LIMIT ? OFFSET ?`
+
+	offset := (page - 1) * limit
+
+	err := m.db.SelectContext(ctx, &albums, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	countQuery := `SELECT COUNT(a.id) FROM albums AS a` + where
+
+	err = m.db.GetContext(ctx, &totalCount, countQuery, args...)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	return albums, totalCount, nil
+}
+
+func (m *AlbumRepository) AlbumExists(ctx context.Context, id int) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT id 
+    				 		FROM albums 
+    				 		WHERE id = ?)`
+	err := m.db.QueryRowContext(ctx, query, id).Scan(&exists)
+	return exists, err
+}
+
+// insertTracks numbers songIDs from 1 in the given order. Songs in the
+// trash count as missing.
+func insertTracks(ctx context.Context, tx *sqlx.Tx, albumID int, songIDs []int) error {
+	query := `INSERT INTO album_tracks(album_id, song_id, track_number)
+              SELECT ?, s.id, ? FROM songs AS s WHERE s.id = ? AND s.deleted_at IS NULL`
+
+	for i, songID := range songIDs {
+		res, err := tx.ExecContext(ctx, query, albumID, i+1, songID)
+		if err != nil {
+			return err
+		}
+
+		if added, err := res.RowsAffected(); err != nil || added == 0 {
+			if err != nil {
+				return err
+			}
+			return models.ErrSongNotFound
+		}
+	}
+
+	return nil
+}
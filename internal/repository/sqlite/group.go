@@ -0,0 +1,181 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	moderncsqlite "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+type GroupRepository struct {
+	db *sqlx.DB
+}
+
+func NewGroupRepository(db *sqlx.DB) *GroupRepository {
+	return &GroupRepository{db: db}
+}
+
+func (m *GroupRepository) Add(ctx context.Context, name string) (int, error) {
+	res, err := m.db.ExecContext(ctx, `INSERT INTO groups(name) VALUES (?)`, name)
+	if isSQLiteError(err, sqlite3.SQLITE_CONSTRAINT_UNIQUE) {
+		return 0, models.ErrGroupExists
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	return int(id), err
+}
+
+// Delete removes the group. It returns models.ErrGroupNotEmpty while the group
+// has songs, counting those in the trash, or albums.
+func (m *GroupRepository) Delete(ctx context.Context, id int) error {
+	query := `DELETE 
+			  FROM groups 
+			  WHERE id = ?`
+	_, err := m.db.ExecContext(ctx, query, id)
+	if isSQLiteError(err, sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY) {
+		return models.ErrGroupNotEmpty
+	}
+
+	return err
+}
+
+// Edit renames the group. The songs of the group are touched as well, so
+// that their versions, and with them their ETags, change with the name.
+func (m *GroupRepository) Edit(ctx context.Context, id int, input *models.EditGroupRequest) error {
+	if input.Name == nil {
+		return nil
+	}
+
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE groups SET name = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`
+	_, err = tx.ExecContext(ctx, query, *input.Name, id)
+	if isSQLiteError(err, sqlite3.SQLITE_CONSTRAINT_UNIQUE) {
+		_ = tx.Rollback()
+		return models.ErrGroupExists
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to execute update query for group with id - %d: %w", id, err)
+	}
+
+	query = `UPDATE songs SET updated_at = CURRENT_TIMESTAMP WHERE group_id = ?`
+	if _, err = tx.ExecContext(ctx, query, id); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to touch songs of group with id - %d: %w", id, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (m *GroupRepository) GetGroup(ctx context.Context, id int) (*models.Group, error) {
+	var group models.Group
+
+	query := `SELECT g.id, g.name, g.created_at, g.updated_at
+              FROM groups AS g
+              WHERE g.id = ?`
+
+	err := m.db.GetContext(ctx, &group, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+func (m *GroupRepository) GetGroups(ctx context.Context, name string, page, limit int) ([]models.Group, int, error) {
+	var (
+		groups     []models.Group
+		conditions string
+		args       []interface{}
+		totalCount int
+	)
+
+	if name != "" {
+		args = append(args, escapeLike(name))
+		conditions = ` WHERE casefold(g.name) LIKE '%' || casefold(?) || '%' ESCAPE '\'`
+	}
+
+	query := `SELECT g.id, g.name, g.created_at, g.updated_at
+              FROM groups AS g` + conditions + ` ORDER BY g.name, g.id LIMIT ? OFFSET ?`
+
+	offset := (page - 1) * limit
+
+	err := m.db.SelectContext(ctx, &groups, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	countQuery := `SELECT COUNT(g.id) FROM groups AS g` + conditions
+
+	err = m.db.GetContext(ctx, &totalCount, countQuery, args...)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	return groups, totalCount, nil
+}
+
+func (m *GroupRepository) GetGroupSongs(ctx context.Context, id, page, limit int) ([]models.Song, int, error) {
+	var (
+		songs      []models.Song
+		totalCount int
+	)
+
+	query := `SELECT s.id, s.group_id, g.name AS group_name, s.song_name, 
+                     s.release_date, s.link, s.created_at, s.updated_at, s.version 
+              FROM songs AS s
+              JOIN groups AS g ON g.id = s.group_id
+              WHERE s.group_id = ? AND s.deleted_at IS NULL
+              ORDER BY s.id LIMIT ? OFFSET ?`
+
+	offset := (page - 1) * limit
+
+	err := m.db.SelectContext(ctx, &songs, query, id, limit, offset)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	countQuery := `SELECT COUNT(s.id) FROM songs AS s WHERE s.group_id = ? AND s.deleted_at IS NULL`
+
+	err = m.db.GetContext(ctx, &totalCount, countQuery, id)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	return songs, totalCount, nil
+}
+
+func (m *GroupRepository) GroupExists(ctx context.Context, id int) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT id 
+    				 		FROM groups 
+    				 		WHERE id = ?)`
+	err := m.db.QueryRowContext(ctx, query, id).Scan(&exists)
+	return exists, err
+}
+
+func isSQLiteError(err error, code int) bool {
+	var sqliteErr *moderncsqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == code
+}
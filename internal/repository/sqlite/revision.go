@@ -0,0 +1,190 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+func (m *SongRepository) GetRevisions(ctx context.Context, songId, page, limit int) ([]models.SongRevision, int, error) {
+	var (
+		revisions  []models.SongRevision
+		totalCount int
+	)
+
+	query := `SELECT r.song_id, r.revision, r.action, r.actor, r.created_at, r.before_state, r.after_state
+              FROM song_revisions AS r
+              WHERE r.song_id = ?
+              ORDER BY r.revision DESC LIMIT ? OFFSET ?`
+
+	offset := (page - 1) * limit
+
+	err := m.db.SelectContext(ctx, &revisions, query, songId, limit, offset)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	countQuery := `SELECT COUNT(r.id) FROM song_revisions AS r WHERE r.song_id = ?`
+
+	err = m.db.GetContext(ctx, &totalCount, countQuery, songId)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	return revisions, totalCount, nil
+}
+
+func (m *SongRepository) GetRevision(ctx context.Context, songId, revision int) (*models.SongRevision, error) {
+	return getRevision(ctx, m.db, songId, revision)
+}
+
+func (m *SongRepository) RestoreRevision(ctx context.Context, songId, revision int) (int, error) {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = lockSongVerses(ctx, tx, songId); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	target, err := getRevision(ctx, tx, songId, revision)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	state := target.State()
+
+	before, err := songSnapshot(ctx, tx, songId)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	groupID, err := resolveGroup(ctx, tx, state.GroupName)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	query := `UPDATE songs SET group_id = ?, song_name = ?, release_date = ?, link = ? WHERE id = ?`
+	_, err = tx.ExecContext(ctx, query, groupID, state.SongName, state.ReleaseDate, state.Link, songId)
+	if isSQLiteError(err, sqlite3.SQLITE_CONSTRAINT_UNIQUE) {
+		_ = tx.Rollback()
+		return 0, models.ErrSongExists
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	if err = replaceVerses(ctx, tx, songId, state.Verses); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	next, err := recordRevision(ctx, tx, songId, models.RevisionRollback, before)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	return next, nil
+}
+
+func getRevision(ctx context.Context, q sqlx.QueryerContext, songId, revision int) (*models.SongRevision, error) {
+	var rev models.SongRevision
+
+	query := `SELECT r.song_id, r.revision, r.action, r.actor, r.created_at, r.before_state, r.after_state
+              FROM song_revisions AS r
+              WHERE r.song_id = ? AND r.revision = ?`
+	err := sqlx.GetContext(ctx, q, &rev, query, songId, revision)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrRevisionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &rev, nil
+}
+
+// songSnapshot reads the current state of a song, for the revisions that
+// record its changes.
+func songSnapshot(ctx context.Context, q sqlx.QueryerContext, songId int) (*models.SongSnapshot, error) {
+	var snapshot models.SongSnapshot
+
+	query := `SELECT g.name, s.song_name, s.release_date, s.link
+              FROM songs AS s
+              JOIN groups AS g ON g.id = s.group_id
+              WHERE s.id = ?`
+	err := q.QueryRowxContext(ctx, query, songId).Scan(&snapshot.GroupName, &snapshot.SongName, &snapshot.ReleaseDate, &snapshot.Link)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrSongNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot.Verses = []string{}
+	query = `SELECT sv.text FROM song_verses AS sv WHERE sv.song_id = ? ORDER BY sv.verse_index`
+	if err = sqlx.SelectContext(ctx, q, &snapshot.Verses, query, songId); err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+// recordRevision stores the change of a song made in tx, from before to the
+// current state, and returns the new revision number.
+func recordRevision(ctx context.Context, tx *sqlx.Tx, songId int, action string, before *models.SongSnapshot) (int, error) {
+	after, err := songSnapshot(ctx, tx, songId)
+	if err != nil {
+		return 0, err
+	}
+
+	return addRevision(ctx, tx, songId, action, before, after)
+}
+
+// addRevision stores a revision of a song, made by the actor of ctx, and
+// returns its number.
+func addRevision(ctx context.Context, tx *sqlx.Tx, songId int, action string, before, after *models.SongSnapshot) (int, error) {
+	var revision int
+
+	query := `INSERT INTO song_revisions(song_id, revision, action, actor, before_state, after_state)
+              SELECT ?, COALESCE(MAX(r.revision), 0) + 1, ?, ?, ?, ?
+              FROM song_revisions AS r
+              WHERE r.song_id = ?
+              RETURNING revision`
+	err := tx.QueryRowContext(ctx, query, songId, action, models.ActorFrom(ctx), before, after, songId).Scan(&revision)
+
+	return revision, err
+}
+
+// replaceVerses replaces all verses of a song with texts.
+func replaceVerses(ctx context.Context, tx *sqlx.Tx, songId int, texts []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM song_verses WHERE song_id = ?`, songId); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO song_verses(song_id, verse_index, text) VALUES (?, ?, ?)`
+	for index := range texts {
+		if _, err := tx.ExecContext(ctx, query, songId, index+1, texts[index]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
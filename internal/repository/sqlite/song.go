@@ -0,0 +1,734 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+type SongRepository struct {
+	db *sqlx.DB
+}
+
+func NewSongRepository(db *sqlx.DB) *SongRepository {
+	return &SongRepository{db: db}
+}
+
+func (m *SongRepository) Add(ctx context.Context, song *models.Song) (int, error) {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	id, err := insertSong(ctx, tx, song)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (m *SongRepository) ImportSongs(ctx context.Context, songs []models.Song) ([]int, error) {
+	ids := make([]int, len(songs))
+
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for i := range songs {
+		if ids[i], err = insertSong(ctx, tx, &songs[i]); err != nil {
+			if errors.Is(err, models.ErrSongExists) {
+				continue
+			}
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// insertSong adds the song, its group if needed and its verses. It returns
+// models.ErrSongExists when the group already has a song of that name.
+func insertSong(ctx context.Context, tx *sqlx.Tx, song *models.Song) (int, error) {
+	groupID, err := resolveGroup(ctx, tx, song.GroupName)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int
+	query := `INSERT INTO songs(group_id, song_name, release_date, link) VALUES (?, ?, ?, ?)
+              ON CONFLICT (group_id, song_name) WHERE deleted_at IS NULL DO NOTHING
+              RETURNING id`
+	err = tx.QueryRowContext(ctx, query,
+		groupID,
+		song.SongName,
+		song.ReleaseDate,
+		song.Link,
+	).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, models.ErrSongExists
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	verseQuery := `INSERT INTO song_verses(song_id, verse_index, text) VALUES (?, ?, ?)`
+
+	verses := models.SplitVerses(song.Text)
+	for index := range verses {
+		if _, err = tx.ExecContext(ctx, verseQuery, id, index+1, verses[index]); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err = recordRevision(ctx, tx, id, models.RevisionCreate, nil); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// Delete moves the song to the trash. Its verses, album tracks and playlist
+// items are kept for a restore; reads of albums and playlists skip them.
+func (m *SongRepository) Delete(ctx context.Context, id int) error {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, `UPDATE songs SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL`, id)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	// A song that was already in the trash has nothing new to record.
+	if deleted > 0 {
+		before, err := songSnapshot(ctx, tx, id)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+
+		if _, err = addRevision(ctx, tx, id, models.RevisionDelete, before, nil); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (m *SongRepository) Edit(ctx context.Context, id int, input *models.EditSongRequest, version int) (int, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if input.Verse != nil {
+		verseExists, err := m.VerseExists(ctx, id, input.Verse.Index)
+		if err != nil {
+			return 0, err
+		}
+
+		if !verseExists {
+			return 0, models.ErrVerseNotFound
+		}
+	}
+
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = lockSongVerses(ctx, tx, id); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	current, err := songVersion(ctx, tx, id)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+	if version != 0 && version != current {
+		_ = tx.Rollback()
+		return 0, models.ErrVersionMismatch
+	}
+
+	if input.GroupName == nil && input.SongName == nil && input.ReleaseDate == nil && input.Link == nil && input.Verse == nil {
+		_ = tx.Rollback()
+		return current, nil
+	}
+
+	before, err := songSnapshot(ctx, tx, id)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	if input.GroupName != nil {
+		groupID, err := resolveGroup(ctx, tx, *input.GroupName)
+		if err != nil {
+			_ = tx.Rollback()
+			return 0, err
+		}
+
+		args = append(args, groupID)
+		conditions = append(conditions, "group_id = ?")
+	}
+
+	if input.SongName != nil {
+		args = append(args, *input.SongName)
+		conditions = append(conditions, "song_name = ?")
+	}
+
+	if input.ReleaseDate != nil {
+		args = append(args, *input.ReleaseDate)
+		conditions = append(conditions, "release_date = ?")
+	}
+
+	if input.Link != nil {
+		args = append(args, *input.Link)
+		conditions = append(conditions, "link = ?")
+	}
+
+	if len(args) > 0 {
+		args = append(args, id)
+		query := `UPDATE songs SET ` + strings.Join(conditions, ", ") + ` WHERE id = ?`
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if isSQLiteError(err, sqlite3.SQLITE_CONSTRAINT_UNIQUE) {
+			_ = tx.Rollback()
+			return 0, models.ErrSongExists
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return 0, fmt.Errorf("failed to execute update query for song with id - %d: %w", id, err)
+		}
+	}
+
+	if input.Verse != nil {
+		verseQuery := `UPDATE song_verses SET text = ? WHERE song_id = ? AND verse_index = ?`
+
+		_, err = tx.ExecContext(ctx, verseQuery, input.Verse.Text, id, input.Verse.Index)
+		if err != nil {
+			_ = tx.Rollback()
+			return 0, fmt.Errorf("failed to execute verse update query for song with id - %d: %w", id, err)
+		}
+	}
+
+	// An edit of a verse alone leaves the song row as it is.
+	if len(args) == 0 {
+		if err = touchSong(ctx, tx, id); err != nil {
+			_ = tx.Rollback()
+			return 0, err
+		}
+	}
+
+	if _, err = recordRevision(ctx, tx, id, models.RevisionEdit, before); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	current, err = songVersion(ctx, tx, id)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	return current, nil
+}
+
+// songVersion returns the version of a song, kept up by the songs_touch
+// trigger.
+func songVersion(ctx context.Context, tx *sqlx.Tx, id int) (int, error) {
+	var version int
+	err := tx.QueryRowContext(ctx, `SELECT version FROM songs WHERE id = ?`, id).Scan(&version)
+	return version, err
+}
+
+// releaseDateKey is the release date as a sortable string, models.Date
+// without a date being the empty string, so that keyset cursors can carry it.
+const releaseDateKey = `COALESCE(s.release_date, '')`
+
+// timestampLayout matches what CURRENT_TIMESTAMP stores.
+const timestampLayout = "2006-01-02 15:04:05"
+
+var songSortExpressions = map[string]string{
+	"id":           "s.id",
+	"group_name":   "g.name",
+	"song_name":    "s.song_name",
+	"release_date": releaseDateKey,
+	"link":         "s.link",
+	"created_at":   "s.created_at",
+	"updated_at":   "s.updated_at",
+	"track_number": "t.track_number",
+}
+
+func (m *SongRepository) GetSong(ctx context.Context, id int) (*models.Song, error) {
+	var song models.Song
+
+	query := `SELECT s.id, s.group_id, g.name AS group_name, s.song_name,
+                     s.release_date, s.link, s.created_at, s.updated_at, s.version
+              FROM songs AS s JOIN groups AS g ON g.id = s.group_id
+              WHERE s.id = ? AND s.deleted_at IS NULL`
+	err := m.db.GetContext(ctx, &song, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrSongNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var verses []models.Verse
+	verseQuery := `SELECT sv.id, sv.song_id, sv.verse_index, sv.text
+                   FROM song_verses AS sv
+                   WHERE sv.song_id = ?
+                   ORDER BY sv.verse_index`
+	if err = m.db.SelectContext(ctx, &verses, verseQuery, id); err != nil {
+		return nil, err
+	}
+	song.Text = models.JoinVerses(verses)
+
+	return &song, nil
+}
+
+func (m *SongRepository) GetSongs(ctx context.Context, filter *models.SongFilter, page *models.Page) ([]models.Song, int, error) {
+	var (
+		songs      []models.Song
+		totalCount int
+	)
+
+	conditions, args := songConditions(filter)
+
+	backward := page.Cursor != nil && page.Cursor.Backward
+	orderBy, err := songOrderBy(filter.SortBy, filter.SortDesc != backward)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	pageConditions, pageArgs := conditions, args
+	if page.Cursor != nil {
+		var keyset string
+		keyset, pageArgs = songKeyset(filter, page.Cursor, args)
+		pageConditions = append(conditions[:len(conditions):len(conditions)], keyset)
+	}
+
+	from, trackNumber := songSource(filter)
+	query := `SELECT s.id, s.group_id, g.name AS group_name, s.song_name, 
+                     s.release_date, s.link, s.created_at, s.updated_at, s.version, ` + trackNumber + ` AS track_number` + from
+
+	if len(pageConditions) > 0 {
+		query += ` WHERE ` + strings.Join(pageConditions, " AND ")
+	}
+
+	query += ` ORDER BY ` + orderBy + ` LIMIT ?`
+	pageArgs = append(pageArgs, page.Limit)
+	if page.Cursor == nil {
+		query += ` OFFSET ?`
+		pageArgs = append(pageArgs, page.Offset)
+	}
+
+	err = m.db.SelectContext(ctx, &songs, query, pageArgs...)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	if backward {
+		slices.Reverse(songs)
+	}
+
+	countQuery := `SELECT COUNT(s.id)` + from
+	if len(conditions) > 0 {
+		countQuery += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+
+	err = m.db.GetContext(ctx, &totalCount, countQuery, args...)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	return songs, totalCount, nil
+}
+
+// exportBatchSize songs are read per query. SQLite has no server-side
+// cursors, and holding one open would block its only connection, so the
+// export seeks from batch to batch like keyset pagination.
+const exportBatchSize = 500
+
+func (m *SongRepository) ExportSongs(ctx context.Context, filter *models.SongFilter, emit func(*models.SongExport) error) error {
+	conditions, args := songConditions(filter)
+
+	orderBy, err := songOrderBy(filter.SortBy, filter.SortDesc)
+	if err != nil {
+		return err
+	}
+
+	from, trackNumber := songSource(filter)
+	query := `SELECT s.id, s.group_id, g.name AS group_name, s.song_name,
+                     s.release_date, s.link, s.created_at, s.updated_at, s.version, ` + trackNumber + ` AS track_number` + from
+
+	var cursor *models.Cursor
+	for {
+		batchConditions, batchArgs := conditions, args
+		if cursor != nil {
+			var keyset string
+			keyset, batchArgs = songKeyset(filter, cursor, args)
+			batchConditions = append(conditions[:len(conditions):len(conditions)], keyset)
+		}
+
+		batchQuery := query
+		if len(batchConditions) > 0 {
+			batchQuery += ` WHERE ` + strings.Join(batchConditions, " AND ")
+		}
+		batchQuery += ` ORDER BY ` + orderBy + ` LIMIT ?`
+		batchArgs = append(batchArgs, exportBatchSize)
+
+		var songs []models.Song
+		if err = m.db.SelectContext(ctx, &songs, batchQuery, batchArgs...); err != nil {
+			return err
+		}
+		if len(songs) == 0 {
+			return nil
+		}
+
+		verses, err := m.batchVerses(ctx, songs)
+		if err != nil {
+			return err
+		}
+
+		for i := range songs {
+			song := &models.SongExport{Song: songs[i], Verses: verses[songs[i].ID]}
+			if song.Verses == nil {
+				song.Verses = []models.Verse{}
+			}
+			song.Text = models.JoinVerses(song.Verses)
+
+			if err = emit(song); err != nil {
+				return err
+			}
+		}
+
+		if len(songs) < exportBatchSize {
+			return nil
+		}
+
+		last := &songs[len(songs)-1]
+		cursor = &models.Cursor{ID: last.ID, Key: last.SortValue(filter.SortBy)}
+	}
+}
+
+// batchVerses returns the verses of songs by song id.
+func (m *SongRepository) batchVerses(ctx context.Context, songs []models.Song) (map[int][]models.Verse, error) {
+	ids := make([]int, 0, len(songs))
+	for _, song := range songs {
+		ids = append(ids, song.ID)
+	}
+
+	query, args, err := sqlx.In(`SELECT sv.id, sv.song_id, sv.verse_index, sv.text
+                                 FROM song_verses AS sv
+                                 WHERE sv.song_id IN (?)
+                                 ORDER BY sv.song_id, sv.verse_index`, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var verses []models.Verse
+	if err = m.db.SelectContext(ctx, &verses, query, args...); err != nil {
+		return nil, err
+	}
+
+	bySong := make(map[int][]models.Verse, len(songs))
+	for _, verse := range verses {
+		bySong[verse.SongId] = append(bySong[verse.SongId], verse)
+	}
+
+	return bySong, nil
+}
+
+func (m *SongRepository) GetSongVerses(ctx context.Context, songId int, page *models.Page) ([]models.Verse, int, error) {
+	var (
+		verses     []models.Verse
+		totalCount int
+	)
+
+	query := `SELECT sv.id, sv.song_id, sv.verse_index, sv.text 
+              FROM song_verses AS sv
+              JOIN songs AS s ON s.id = sv.song_id AND s.deleted_at IS NULL
+              WHERE sv.song_id = ?`
+
+	args := []interface{}{songId}
+	direction := "ASC"
+
+	if c := page.Cursor; c != nil {
+		index, err := strconv.Atoi(c.Key)
+		if err != nil {
+			return nil, totalCount, fmt.Errorf("invalid verse cursor key %q: %w", c.Key, err)
+		}
+
+		op := ">"
+		if c.Backward {
+			op, direction = "<", "DESC"
+		}
+
+		query += ` AND (sv.verse_index, sv.id) ` + op + ` (?, ?)`
+		args = append(args, index, c.ID)
+	}
+
+	query += ` ORDER BY sv.verse_index ` + direction + `, sv.id ` + direction + ` LIMIT ?`
+	args = append(args, page.Limit)
+	if page.Cursor == nil {
+		query += ` OFFSET ?`
+		args = append(args, page.Offset)
+	}
+
+	err := m.db.SelectContext(ctx, &verses, query, args...)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	if page.Cursor != nil && page.Cursor.Backward {
+		slices.Reverse(verses)
+	}
+
+	countQuery := `SELECT COUNT(sv.id)
+                   FROM song_verses AS sv
+                   JOIN songs AS s ON s.id = sv.song_id AND s.deleted_at IS NULL
+                   WHERE sv.song_id = ?`
+
+	err = m.db.GetContext(ctx, &totalCount, countQuery, songId)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	return verses, totalCount, nil
+}
+
+func (m *SongRepository) SearchVerses(ctx context.Context, text string, page, limit int) ([]models.VerseSearchResult, int, error) {
+	var (
+		results    []models.VerseSearchResult
+		totalCount int
+	)
+
+	match := ftsQuery(text)
+	if match == "" {
+		return nil, totalCount, nil
+	}
+
+	query := `SELECT sv.song_id, g.name AS group_name, s.song_name, sv.verse_index,
+                     snippet(song_verses_fts, 0, '<b>', '</b>', '...', 32) AS snippet,
+                     -bm25(song_verses_fts) AS rank
+              FROM song_verses_fts
+              JOIN song_verses AS sv ON sv.id = song_verses_fts.rowid
+              JOIN songs AS s ON s.id = sv.song_id AND s.deleted_at IS NULL
+              JOIN groups AS g ON g.id = s.group_id
+              WHERE song_verses_fts MATCH ?
+              ORDER BY rank DESC, sv.song_id, sv.verse_index
+              LIMIT ? OFFSET ?`
+
+	offset := (page - 1) * limit
+
+	err := m.db.SelectContext(ctx, &results, query, match, limit, offset)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	countQuery := `SELECT COUNT(*)
+                   FROM song_verses_fts
+                   JOIN song_verses AS sv ON sv.id = song_verses_fts.rowid
+                   JOIN songs AS s ON s.id = sv.song_id AND s.deleted_at IS NULL
+                   WHERE song_verses_fts MATCH ?`
+
+	err = m.db.GetContext(ctx, &totalCount, countQuery, match)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	return results, totalCount, nil
+}
+
+func (m *SongRepository) SongExists(ctx context.Context, id int) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT id 
+    				 		FROM songs 
+    				 		WHERE id = ? AND deleted_at IS NULL)`
+	err := m.db.QueryRowContext(ctx, query, id).Scan(&exists)
+	return exists, err
+}
+
+func (m *SongRepository) VerseExists(ctx context.Context, songId, index int) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT sv.id 
+    				 		FROM song_verses AS sv
+    				 		JOIN songs AS s ON s.id = sv.song_id AND s.deleted_at IS NULL
+    				 		WHERE sv.song_id = ? AND sv.verse_index = ?)`
+	err := m.db.QueryRowContext(ctx, query, songId, index).Scan(&exists)
+	return exists, err
+}
+
+func songConditions(filter *models.SongFilter) ([]string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	// Songs in the trash are left out of every listing.
+	conditions = append(conditions, "s.deleted_at IS NULL")
+
+	if filter.AlbumID != 0 {
+		args = append(args, filter.AlbumID)
+		conditions = append(conditions, "t.album_id = ?")
+	}
+
+	if filter.Group != "" {
+		args = append(args, escapeLike(filter.Group))
+		conditions = append(conditions, `casefold(g.name) LIKE '%' || casefold(?) || '%' ESCAPE '\'`)
+	}
+
+	if filter.Song != "" {
+		args = append(args, escapeLike(filter.Song))
+		conditions = append(conditions, `casefold(s.song_name) LIKE '%' || casefold(?) || '%' ESCAPE '\'`)
+	}
+
+	if filter.ReleasedAfter != nil {
+		args = append(args, filter.ReleasedAfter.Format(models.DateLayout))
+		conditions = append(conditions, "s.release_date >= ?")
+	}
+
+	if filter.ReleasedBefore != nil {
+		args = append(args, filter.ReleasedBefore.Format(models.DateLayout))
+		conditions = append(conditions, "s.release_date <= ?")
+	}
+
+	if filter.CreatedAfter != nil {
+		args = append(args, filter.CreatedAfter.UTC().Format(timestampLayout))
+		conditions = append(conditions, "s.created_at >= ?")
+	}
+
+	if filter.CreatedBefore != nil {
+		args = append(args, filter.CreatedBefore.UTC().Format(timestampLayout))
+		conditions = append(conditions, "s.created_at <= ?")
+	}
+
+	return conditions, args
+}
+
+func songOrderBy(sortBy string, desc bool) (string, error) {
+	if sortBy == "" {
+		sortBy = "id"
+	}
+
+	expr, ok := songSortExpressions[sortBy]
+	if !ok {
+		return "", fmt.Errorf("unknown sort column %q", sortBy)
+	}
+
+	direction := "ASC"
+	if desc {
+		direction = "DESC"
+	}
+
+	return fmt.Sprintf("%s %s, s.id %s", expr, direction, direction), nil
+}
+
+// songKeyset builds the predicate selecting rows past the cursor in the
+// direction it points to, comparing (sort key, id) row values.
+func songKeyset(filter *models.SongFilter, c *models.Cursor, args []interface{}) (string, []interface{}) {
+	op := ">"
+	if filter.SortDesc != c.Backward {
+		op = "<"
+	}
+
+	args = args[:len(args):len(args)]
+
+	switch filter.SortBy {
+	case "", "id":
+		return "s.id " + op + " ?", append(args, c.ID)
+	case "created_at", "updated_at":
+		return "(" + songSortExpressions[filter.SortBy] + ", s.id) " + op + " (datetime(?), ?)", append(args, c.Key, c.ID)
+	case "track_number":
+		return "(t.track_number, s.id) " + op + " (CAST(? AS INTEGER), ?)", append(args, c.Key, c.ID)
+	default:
+		return "(" + songSortExpressions[filter.SortBy] + ", s.id) " + op + " (?, ?)", append(args, c.Key, c.ID)
+	}
+}
+
+// songSource returns the FROM clause of GetSongs and the expression selected
+// as the track number; album tracks are only joined when listing an album.
+func songSource(filter *models.SongFilter) (string, string) {
+	from := ` FROM songs AS s JOIN groups AS g ON g.id = s.group_id`
+	if filter.AlbumID == 0 {
+		return from, "NULL"
+	}
+	return from + ` JOIN album_tracks AS t ON t.song_id = s.id`, "t.track_number"
+}
+
+// escapeLike escapes the LIKE wildcards in s so it is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// resolveGroup returns the id of the group with the given name, creating the
+// group if it does not exist yet.
+func resolveGroup(ctx context.Context, q sqlx.ExtContext, name string) (int, error) {
+	var id int
+
+	_, err := q.ExecContext(ctx, `INSERT INTO groups (name) VALUES (?) ON CONFLICT (name) DO NOTHING`, name)
+	if err != nil {
+		return id, fmt.Errorf("resolve group %q: %w", name, err)
+	}
+
+	err = q.QueryRowxContext(ctx, `SELECT id FROM groups WHERE name = ?`, name).Scan(&id)
+	if err != nil {
+		return id, fmt.Errorf("resolve group %q: %w", name, err)
+	}
+
+	return id, nil
+}
+
+// ftsQuery quotes every word of text so that FTS5 treats user input as plain
+// terms (implicitly AND-ed) rather than query syntax.
+func ftsQuery(text string) string {
+	terms := strings.Fields(text)
+	for i, term := range terms {
+		terms[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+	}
+	return strings.Join(terms, " ")
+}
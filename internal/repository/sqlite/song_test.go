@@ -0,0 +1,64 @@
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/LionJr/music-library/config"
+	"github.com/LionJr/music-library/db"
+	"github.com/LionJr/music-library/internal/repository/repotest"
+	"github.com/LionJr/music-library/internal/repository/sqlite"
+	"github.com/LionJr/music-library/internal/service/song"
+	"github.com/jmoiron/sqlx"
+)
+
+func openDB(t *testing.T) *sqlx.DB {
+	cfg := &config.SQLite{Path: filepath.Join(t.TempDir(), "music.db")}
+
+	sqliteDB, err := db.NewSQLiteDB(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = sqliteDB.Close() })
+
+	return sqliteDB
+}
+
+func TestSongRepository(t *testing.T) {
+	repotest.RunSongRepo(t, func(t *testing.T) song.Repo {
+		return sqlite.NewSongRepository(openDB(t))
+	})
+}
+
+func newRepos(t *testing.T) *repotest.Repos {
+	sqliteDB := openDB(t)
+	return &repotest.Repos{
+		Songs:     sqlite.NewSongRepository(sqliteDB),
+		Groups:    sqlite.NewGroupRepository(sqliteDB),
+		Albums:    sqlite.NewAlbumRepository(sqliteDB),
+		Playlists: sqlite.NewPlaylistRepository(sqliteDB),
+		Users:     sqlite.NewUserRepository(sqliteDB),
+		Keys:      sqlite.NewAPIKeyRepository(sqliteDB),
+	}
+}
+
+func TestGroupRepository(t *testing.T) {
+	repotest.RunGroupRepo(t, newRepos)
+}
+
+func TestAlbumRepository(t *testing.T) {
+	repotest.RunAlbumRepo(t, newRepos)
+}
+
+func TestPlaylistRepository(t *testing.T) {
+	repotest.RunPlaylistRepo(t, newRepos)
+}
+
+func TestUserRepository(t *testing.T) {
+	repotest.RunUserRepo(t, newRepos)
+}
+
+func TestAPIKeyRepository(t *testing.T) {
+	repotest.RunAPIKeyRepo(t, newRepos)
+}
@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+func (m *SongRepository) GetTrash(ctx context.Context, page, limit int) ([]models.Song, int, error) {
+	var (
+		songs      []models.Song
+		totalCount int
+	)
+
+	query := `SELECT s.id, s.group_id, g.name AS group_name, s.song_name,
+                     s.release_date, s.link, s.created_at, s.updated_at, s.version, s.deleted_at
+              FROM songs AS s
+              JOIN groups AS g ON g.id = s.group_id
+              WHERE s.deleted_at IS NOT NULL
+              ORDER BY s.deleted_at DESC, s.id DESC LIMIT ? OFFSET ?`
+
+	offset := (page - 1) * limit
+
+	err := m.db.SelectContext(ctx, &songs, query, limit, offset)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	countQuery := `SELECT COUNT(s.id) FROM songs AS s WHERE s.deleted_at IS NOT NULL`
+
+	err = m.db.GetContext(ctx, &totalCount, countQuery)
+	if err != nil {
+		return nil, totalCount, err
+	}
+
+	return songs, totalCount, nil
+}
+
+func (m *SongRepository) RestoreSong(ctx context.Context, id int) error {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	var songId int
+	query := `SELECT s.id FROM songs AS s WHERE s.id = ? AND s.deleted_at IS NOT NULL`
+	err = tx.QueryRowContext(ctx, query, id).Scan(&songId)
+	if errors.Is(err, sql.ErrNoRows) {
+		_ = tx.Rollback()
+		return models.ErrSongNotFound
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE songs SET deleted_at = NULL WHERE id = ?`, id)
+	if isSQLiteError(err, sqlite3.SQLITE_CONSTRAINT_UNIQUE) {
+		_ = tx.Rollback()
+		return models.ErrSongExists
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if _, err = recordRevision(ctx, tx, id, models.RevisionRestore, nil); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (m *SongRepository) PurgeTrash(ctx context.Context, olderThan time.Duration) (int, error) {
+	// deleted_at holds CURRENT_TIMESTAMP text, which datetime() produces as
+	// well, so the two compare in time order.
+	query := `DELETE FROM songs WHERE deleted_at < datetime('now', ?)`
+	res, err := m.db.ExecContext(ctx, query, fmt.Sprintf("%+d seconds", -int64(olderThan.Seconds())))
+	if err != nil {
+		return 0, err
+	}
+
+	purged, err := res.RowsAffected()
+	return int(purged), err
+}
@@ -0,0 +1,134 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+type UserRepository struct {
+	db *sqlx.DB
+}
+
+func NewUserRepository(db *sqlx.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+func (m *UserRepository) AddUser(ctx context.Context, username, passwordHash string) (int, error) {
+	query := `INSERT INTO users(username, password_hash) VALUES (?, ?)`
+	res, err := m.db.ExecContext(ctx, query, username, passwordHash)
+	if isSQLiteError(err, sqlite3.SQLITE_CONSTRAINT_UNIQUE) {
+		return 0, models.ErrUserExists
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	return int(id), err
+}
+
+func (m *UserRepository) GetUserByName(ctx context.Context, username string) (*models.User, error) {
+	var user models.User
+
+	query := `SELECT u.id, u.username, u.password_hash, u.created_at, u.updated_at
+              FROM users AS u
+              WHERE u.username = ?`
+
+	err := m.db.GetContext(ctx, &user, query, username)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (m *UserRepository) AddRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	query := `INSERT INTO refresh_tokens(user_id, token_hash, expires_at) VALUES (?, ?, ?)`
+	_, err := m.db.ExecContext(ctx, query, token.UserID, token.TokenHash, token.ExpiresAt)
+	return err
+}
+
+func (m *UserRepository) RotateRefreshToken(ctx context.Context, oldHash string, next *models.RefreshToken, now time.Time) (*models.User, error) {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var current models.RefreshToken
+	query := `SELECT rt.id, rt.user_id, rt.token_hash, rt.expires_at, rt.revoked_at
+              FROM refresh_tokens AS rt
+              WHERE rt.token_hash = ?`
+
+	err = tx.GetContext(ctx, &current, query, oldHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		_ = tx.Rollback()
+		return nil, models.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	if current.RevokedAt != nil {
+		query = `UPDATE refresh_tokens SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL`
+		if _, err = tx.ExecContext(ctx, query, now, current.UserID); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+
+		if err = tx.Commit(); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+		return nil, models.ErrRefreshTokenReused
+	}
+
+	if !now.Before(current.ExpiresAt) {
+		_ = tx.Rollback()
+		return nil, models.ErrInvalidRefreshToken
+	}
+
+	if _, err = tx.ExecContext(ctx, `UPDATE refresh_tokens SET revoked_at = ? WHERE id = ?`, now, current.ID); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	query = `INSERT INTO refresh_tokens(user_id, token_hash, expires_at) VALUES (?, ?, ?)`
+	if _, err = tx.ExecContext(ctx, query, current.UserID, next.TokenHash, next.ExpiresAt); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	next.UserID = current.UserID
+
+	var user models.User
+	query = `SELECT u.id, u.username, u.password_hash, u.created_at, u.updated_at
+             FROM users AS u
+             WHERE u.id = ?`
+	if err = tx.GetContext(ctx, &user, query, current.UserID); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (m *UserRepository) RevokeRefreshToken(ctx context.Context, hash string, now time.Time) error {
+	query := `UPDATE refresh_tokens SET revoked_at = ? WHERE token_hash = ? AND revoked_at IS NULL`
+	_, err := m.db.ExecContext(ctx, query, now, hash)
+	return err
+}
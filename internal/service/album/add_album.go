@@ -0,0 +1,76 @@
+package album
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Add                     godoc
+// @Summary                Adding a new album
+// @Description            Adding a new album of an existing group with its songs listed in track order
+// @Tags                   Album
+// @Accept                 json
+// @Produce                json
+// @Param req              body   models.NewAlbumRequest true  "album to add"
+// @Success      		   200    {object}  models.NewAlbumResponse
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   401    {object}  models.ErrorResponse
+// @Failure      		   403    {object}  models.ErrorResponse
+// @Failure      		   404    {object}  models.ErrorResponse
+// @Failure      		   409    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Security     		   BearerAuth
+// @Security     		   ApiKeyAuth
+// @Router       		   /albums [post]
+func (s *Service) Add(ctx *gin.Context) {
+	var req models.NewAlbumRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		s.Logger.Info("album.Add: unmarshal request body", zap.Error(err))
+		respond.Error(ctx, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.GroupID <= 0 {
+		respond.ValidationErrors(ctx, models.FieldError{Field: "group_id", Message: "invalid group id"})
+		return
+	}
+
+	validationResult := validateInput(&models.EditAlbumRequest{
+		Title:       &req.Title,
+		ReleaseDate: &req.ReleaseDate,
+		CoverLink:   &req.CoverLink,
+		Tracks:      &req.Tracks,
+	})
+	if len(validationResult) > 0 {
+		respond.ValidationErrors(ctx, validationResult...)
+		return
+	}
+
+	albumId, err := s.Repo.Add(ctx, &req)
+	if err != nil {
+		s.Logger.Info("album.Add: ", zap.Error(err))
+		switch {
+		case errors.Is(err, models.ErrAlbumExists):
+			respond.DomainError(ctx, err, "album already exists", http.StatusConflict)
+		case errors.Is(err, models.ErrGroupNotFound):
+			respond.DomainError(ctx, err, "group does not exist", http.StatusNotFound)
+		case errors.Is(err, models.ErrSongNotFound):
+			respond.DomainError(ctx, err, "track song does not exist", http.StatusNotFound)
+		default:
+			respond.Error(ctx, "album add error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	resp := models.NewAlbumResponse{
+		Message: "Album successfully added",
+		AlbumID: albumId,
+	}
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
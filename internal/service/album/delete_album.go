@@ -0,0 +1,58 @@
+package album
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Delete godoc
+// @Summary      	     Remove album
+// @Description  	     Remove album and its track listing by id, the songs are kept
+// @Tags         	     Album
+// @Accept       	     json
+// @Produce      	     json
+// @Param 			     id 	             path      integer                true   "album id"
+// @Success      	     200  		         {object}  string
+// @Failure      	     400  			     {object}  models.ErrorResponse
+// @Failure      	     401  			     {object}  models.ErrorResponse
+// @Failure      	     403  			     {object}  models.ErrorResponse
+// @Failure      	     404  			     {object}  models.ErrorResponse
+// @Failure      	     500  			     {object}  models.ErrorResponse
+// @Security     	     BearerAuth
+// @Security     	     ApiKeyAuth
+// @Router       	     /albums/{id} [delete]
+func (s *Service) Delete(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	albumId, err := strconv.Atoi(idParam)
+	if err != nil || albumId <= 0 {
+		s.Logger.Info("album.Delete: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid album id", http.StatusBadRequest)
+		return
+	}
+
+	exists, err := s.Repo.AlbumExists(ctx, albumId)
+	if err != nil {
+		s.Logger.Info("album.Delete: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		respond.DomainError(ctx, models.ErrAlbumNotFound, "album does not exist", http.StatusNotFound)
+		return
+	}
+
+	err = s.Repo.Delete(ctx, albumId)
+	if err != nil {
+		s.Logger.Info("album.Delete: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccessResponse(ctx, "Successfully deleted", http.StatusOK)
+}
@@ -0,0 +1,81 @@
+package album
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Edit godoc
+// @Summary     Update album
+// @Description Update album properties by album id, tracks replaces the whole track listing
+// @Tags        Album
+// @Accept      json
+// @Produce     json
+// @Param       req  body     models.EditAlbumRequest true "Album field(s) need to be updated"
+// @Param       id   path     integer                 true "Album id"
+// @Success     200  {object} models.SuccessResponse "Album successfully updated"
+// @Failure     400  {object} models.ErrorResponse
+// @Failure     401  {object} models.ErrorResponse
+// @Failure     403  {object} models.ErrorResponse
+// @Failure     404  {object} models.ErrorResponse
+// @Failure     409  {object} models.ErrorResponse
+// @Failure     500  {object} models.ErrorResponse
+// @Security    BearerAuth
+// @Security    ApiKeyAuth
+// @Router      /albums/{id} [patch]
+func (s *Service) Edit(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	albumId, err := strconv.Atoi(idParam)
+	if err != nil || albumId <= 0 {
+		s.Logger.Info("album.Edit: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid album id", http.StatusBadRequest)
+		return
+	}
+
+	var req models.EditAlbumRequest
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		s.Logger.Info("album.Edit: unmarshal request body", zap.Error(err))
+		respond.Error(ctx, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	exists, err := s.Repo.AlbumExists(ctx, albumId)
+	if err != nil {
+		s.Logger.Info("album.Edit: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		respond.DomainError(ctx, models.ErrAlbumNotFound, "album does not exist", http.StatusNotFound)
+		return
+	}
+
+	validationResult := validateInput(&req)
+	if len(validationResult) > 0 {
+		respond.ValidationErrors(ctx, validationResult...)
+		return
+	}
+
+	err = s.Repo.Edit(ctx, albumId, &req)
+	if err != nil {
+		s.Logger.Error("album.Edit", zap.Error(err))
+		switch {
+		case errors.Is(err, models.ErrAlbumExists):
+			respond.DomainError(ctx, err, "album already exists", http.StatusConflict)
+		case errors.Is(err, models.ErrSongNotFound):
+			respond.DomainError(ctx, err, "track song does not exist", http.StatusNotFound)
+		default:
+			respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	sendSuccessResponse(ctx, models.SuccessResponse{Message: "Album successfully updated"}, http.StatusOK)
+}
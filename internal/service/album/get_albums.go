@@ -0,0 +1,103 @@
+package album
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/LionJr/music-library/internal/service/validate"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GetAlbums               godoc
+// @Summary                Get albums
+// @Description            Get albums filtered by group and title with pagination, default pagination value will be 3
+// @Tags                   Album
+// @Accept                 json
+// @Produce                json
+// @Param   	           group_id  query     int     false       "group id"
+// @Param   	           title     query     string  false       "case-insensitive substring of the album title"
+// @Param   	           page      query     int     false       "page number in pagination"
+// @Param  		           limit     query     int     false       "number of elements in one page, at most 100"
+// @Success      		   200    {object}  models.GetAlbumsResponse
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Router       		   /albums [get]
+func (s *Service) GetAlbums(ctx *gin.Context) {
+	filter := &models.AlbumFilter{
+		Title: validate.Query(ctx.Query("title")),
+	}
+
+	if groupParam := ctx.Query("group_id"); groupParam != "" {
+		groupId, err := strconv.Atoi(groupParam)
+		if err != nil || groupId <= 0 {
+			respond.Error(ctx, "invalid group id", http.StatusBadRequest)
+			return
+		}
+		filter.GroupID = groupId
+	}
+
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = models.DefaultPaginationPage
+	}
+
+	limit, err := strconv.Atoi(ctx.Query("limit"))
+	if err != nil || limit < 1 {
+		limit = models.DefaultPaginationSize
+	}
+	limit = min(limit, models.MaxPaginationSize)
+
+	albums, totalAlbumCount, err := s.Repo.GetAlbums(ctx, filter, page, limit)
+	if err != nil {
+		s.Logger.Info("album.GetAlbums", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := models.GetAlbumsResponse{
+		Albums:          albums,
+		TotalAlbumCount: totalAlbumCount,
+		Page:            page,
+	}
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
+
+// GetAlbum                godoc
+// @Summary                Get album
+// @Description            Get album by id with its track listing
+// @Tags                   Album
+// @Accept                 json
+// @Produce                json
+// @Param   	           id      path      int     true          "album id"
+// @Success      		   200    {object}  models.Album
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   404    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Router       		   /albums/{id} [get]
+func (s *Service) GetAlbum(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	albumId, err := strconv.Atoi(idParam)
+	if err != nil || albumId <= 0 {
+		s.Logger.Info("album.GetAlbum: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid album id", http.StatusBadRequest)
+		return
+	}
+
+	album, err := s.Repo.GetAlbum(ctx, albumId)
+	if err != nil {
+		s.Logger.Info("album.GetAlbum: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if album == nil {
+		respond.DomainError(ctx, models.ErrAlbumNotFound, "album does not exist", http.StatusNotFound)
+		return
+	}
+
+	sendSuccessResponse(ctx, album, http.StatusOK)
+}
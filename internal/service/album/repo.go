@@ -0,0 +1,16 @@
+package album
+
+import (
+	"context"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+type Repo interface {
+	Add(ctx context.Context, input *models.NewAlbumRequest) (int, error)
+	Delete(ctx context.Context, id int) error
+	Edit(ctx context.Context, id int, input *models.EditAlbumRequest) error
+	GetAlbum(ctx context.Context, id int) (*models.Album, error)
+	GetAlbums(ctx context.Context, filter *models.AlbumFilter, page, limit int) ([]models.Album, int, error)
+	AlbumExists(ctx context.Context, id int) (bool, error)
+}
@@ -0,0 +1,57 @@
+package album
+
+import (
+	"time"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/validate"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	layout              = "02.01.2006"
+	maxAlbumTitleLength = 200
+	maxCoverLinkLength  = 255
+)
+
+func sendSuccessResponse(ctx *gin.Context, data interface{}, status int) {
+	ctx.JSON(status, data)
+}
+
+// validateInput checks the album fields that are set and normalises the
+// title in place.
+func validateInput(input *models.EditAlbumRequest) []models.FieldError {
+	var validationErrors validate.Errors
+
+	if input.Title != nil {
+		validationErrors.Name("title", "album title", input.Title, maxAlbumTitleLength)
+	}
+
+	if input.ReleaseDate != nil && *input.ReleaseDate != "" {
+		_, err := time.Parse(layout, *input.ReleaseDate)
+		if err != nil {
+			validationErrors.Add("release_date", "invalid release date")
+		}
+	}
+
+	if input.CoverLink != nil && *input.CoverLink != "" {
+		validationErrors.Link("cover_link", "cover link", *input.CoverLink, maxCoverLinkLength)
+	}
+
+	if input.Tracks != nil {
+		seen := make(map[int]bool, len(*input.Tracks))
+		for _, songID := range *input.Tracks {
+			if songID <= 0 {
+				validationErrors.Add("tracks", "invalid track song id")
+				break
+			}
+			if seen[songID] {
+				validationErrors.Add("tracks", "song appears on the album twice")
+				break
+			}
+			seen[songID] = true
+		}
+	}
+
+	return validationErrors
+}
@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GetAPIKeys              godoc
+// @Summary                Get API keys
+// @Description            Get all issued API keys, including revoked ones. Admins only
+// @Tags                   Admin
+// @Accept                 json
+// @Produce                json
+// @Success      		   200    {object}  models.GetAPIKeysResponse
+// @Failure      		   401    {object}  models.ErrorResponse
+// @Failure      		   403    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Security     		   BearerAuth
+// @Router       		   /admin/api-keys [get]
+func (s *Service) GetAPIKeys(ctx *gin.Context) {
+	keys, err := s.Keys.GetAPIKeys(ctx)
+	if err != nil {
+		s.Logger.Info("auth.GetAPIKeys: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccessResponse(ctx, models.GetAPIKeysResponse{APIKeys: keys}, http.StatusOK)
+}
@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"slices"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/LionJr/music-library/internal/service/validate"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// IssueAPIKey             godoc
+// @Summary                Issuing an API key
+// @Description            Issuing an API key with the given scopes. The key is only shown in this response. Admins only
+// @Tags                   Admin
+// @Accept                 json
+// @Produce                json
+// @Param req              body   models.NewAPIKeyRequest true  "key name and scopes"
+// @Success      		   200    {object}  models.NewAPIKeyResponse
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   401    {object}  models.ErrorResponse
+// @Failure      		   403    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Security     		   BearerAuth
+// @Router       		   /admin/api-keys [post]
+func (s *Service) IssueAPIKey(ctx *gin.Context) {
+	var req models.NewAPIKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		s.Logger.Info("auth.IssueAPIKey: unmarshal request body", zap.Error(err))
+		respond.Error(ctx, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var validationErrors validate.Errors
+	validationErrors.Name("name", "key name", &req.Name, maxAPIKeyNameLength)
+	if len(req.Scopes) == 0 {
+		validationErrors.Add("scopes", "at least one scope is required")
+	}
+
+	scopes := make(models.Scopes, 0, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		if !slices.Contains(models.KnownScopes, scope) {
+			validationErrors.Add("scopes", "unknown scope "+scope)
+			continue
+		}
+		if !scopes.Has(scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		respond.ValidationErrors(ctx, validationErrors...)
+		return
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		s.Logger.Info("auth.IssueAPIKey: generate key", zap.Error(err))
+		respond.Error(ctx, "api key issue error", http.StatusInternalServerError)
+		return
+	}
+	secret := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(raw)
+
+	userId := ctx.GetInt("user_id")
+	key := models.APIKey{
+		Name:      req.Name,
+		Prefix:    secret[:apiKeyShownLength],
+		KeyHash:   hashToken(secret),
+		Scopes:    scopes,
+		CreatedBy: &userId,
+	}
+
+	keyId, err := s.Keys.AddAPIKey(ctx, &key)
+	if err != nil {
+		s.Logger.Info("auth.IssueAPIKey: ", zap.Error(err))
+		respond.Error(ctx, "api key issue error", http.StatusInternalServerError)
+		return
+	}
+
+	s.Logger.Info("auth.IssueAPIKey: key issued", zap.Int("api_key_id", keyId), zap.Int("user_id", userId))
+
+	resp := models.NewAPIKeyResponse{
+		Message: "API key successfully issued",
+		ID:      keyId,
+		Key:     secret,
+		Scopes:  scopes,
+	}
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Login                   godoc
+// @Summary                Logging in
+// @Description            Exchanging a username and password for an access token and a refresh token
+// @Tags                   Auth
+// @Accept                 json
+// @Produce                json
+// @Param req              body   models.LoginRequest true  "credentials"
+// @Success      		   200    {object}  models.TokenResponse
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   401    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Router       		   /auth/login [post]
+func (s *Service) Login(ctx *gin.Context) {
+	var req models.LoginRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		s.Logger.Info("auth.Login: unmarshal request body", zap.Error(err))
+		respond.Error(ctx, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := s.Repo.GetUserByName(ctx, req.Username)
+	if err != nil {
+		s.Logger.Info("auth.Login: ", zap.Error(err))
+		respond.Error(ctx, "login error", http.StatusInternalServerError)
+		return
+	}
+
+	// Unknown users are checked against a dummy hash so that they take as
+	// long as wrong passwords.
+	hash := dummyPasswordHash()
+	if user != nil {
+		hash = []byte(user.PasswordHash)
+	}
+
+	if bcrypt.CompareHashAndPassword(hash, []byte(req.Password)) != nil || user == nil {
+		respond.Error(ctx, "invalid username or password", http.StatusUnauthorized)
+		return
+	}
+
+	now := time.Now()
+	refreshToken, stored, err := s.newRefreshToken(now)
+	if err != nil {
+		s.Logger.Info("auth.Login: generate refresh token", zap.Error(err))
+		respond.Error(ctx, "login error", http.StatusInternalServerError)
+		return
+	}
+	stored.UserID = user.ID
+
+	if err = s.Repo.AddRefreshToken(ctx, stored); err != nil {
+		s.Logger.Info("auth.Login: ", zap.Error(err))
+		respond.Error(ctx, "login error", http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := s.issueTokens(user, refreshToken, now)
+	if err != nil {
+		s.Logger.Info("auth.Login: sign access token", zap.Error(err))
+		respond.Error(ctx, "login error", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
@@ -0,0 +1,83 @@
+package auth_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+func TestRegisterRefusesAdminNames(t *testing.T) {
+	s := newTestService("root")
+
+	for _, username := range []string{"root", "ROOT"} {
+		body := `{"username":"` + username + `","password":"correct horse"}`
+		w, _ := serve(http.MethodPost, "/auth/register", body, nil, s.Register)
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s: got status %d, want %d", username, w.Code, http.StatusForbidden)
+		}
+	}
+}
+
+func TestLogin(t *testing.T) {
+	s := newTestService()
+	register(t, s, "alice")
+
+	for _, tc := range []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"wrong password", `{"username":"alice","password":"wrong horse"}`, http.StatusUnauthorized},
+		{"unknown user", `{"username":"bob","password":"correct horse"}`, http.StatusUnauthorized},
+		{"invalid body", `{`, http.StatusBadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w, _ := serve(http.MethodPost, "/auth/login", tc.body, nil, s.Login)
+			if w.Code != tc.status {
+				t.Errorf("got status %d, want %d", w.Code, tc.status)
+			}
+		})
+	}
+}
+
+func TestRefreshRotatesTokens(t *testing.T) {
+	s := newTestService()
+	tokens := register(t, s, "alice")
+	first := `{"refresh_token":"` + tokens.RefreshToken + `"}`
+
+	w, _ := serve(http.MethodPost, "/auth/refresh", first, nil, s.Refresh)
+	if w.Code != http.StatusOK {
+		t.Fatalf("refresh: got status %d: %s", w.Code, w.Body)
+	}
+	rotated := decode[models.TokenResponse](t, w)
+	if rotated.RefreshToken == tokens.RefreshToken || rotated.AccessToken == "" {
+		t.Fatalf("refresh: got %+v, want new tokens", rotated)
+	}
+
+	w, ctx := serve(http.MethodGet, "/", "", bearer(rotated.AccessToken), s.Authenticate)
+	if w.Code != http.StatusOK || ctx.GetString("username") != "alice" {
+		t.Errorf("new access token: got status %d, username %q", w.Code, ctx.GetString("username"))
+	}
+
+	// Presenting the first token again revokes the rotated one too.
+	if w, _ = serve(http.MethodPost, "/auth/refresh", first, nil, s.Refresh); w.Code != http.StatusUnauthorized {
+		t.Errorf("reuse: got status %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+	second := `{"refresh_token":"` + rotated.RefreshToken + `"}`
+	if w, _ = serve(http.MethodPost, "/auth/refresh", second, nil, s.Refresh); w.Code != http.StatusUnauthorized {
+		t.Errorf("after reuse: got status %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestLogoutRevokesRefreshToken(t *testing.T) {
+	s := newTestService()
+	body := `{"refresh_token":"` + register(t, s, "alice").RefreshToken + `"}`
+
+	if w, _ := serve(http.MethodPost, "/auth/logout", body, nil, s.Logout); w.Code != http.StatusOK {
+		t.Fatalf("logout: got status %d: %s", w.Code, w.Body)
+	}
+	if w, _ := serve(http.MethodPost, "/auth/refresh", body, nil, s.Refresh); w.Code != http.StatusUnauthorized {
+		t.Errorf("refresh after logout: got status %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
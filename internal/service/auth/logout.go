@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Logout                  godoc
+// @Summary                Logging out
+// @Description            Revoking a refresh token. Access tokens stay valid until they expire
+// @Tags                   Auth
+// @Accept                 json
+// @Produce                json
+// @Param req              body   models.RefreshRequest true  "refresh token"
+// @Success      		   200    {object}  models.SuccessResponse
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Router       		   /auth/logout [post]
+func (s *Service) Logout(ctx *gin.Context) {
+	var req models.RefreshRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil || req.RefreshToken == "" {
+		s.Logger.Info("auth.Logout: unmarshal request body", zap.Error(err))
+		respond.Error(ctx, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.Repo.RevokeRefreshToken(ctx, hashToken(req.RefreshToken), time.Now()); err != nil {
+		s.Logger.Info("auth.Logout: ", zap.Error(err))
+		respond.Error(ctx, "logout error", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccessResponse(ctx, models.SuccessResponse{Message: "Successfully logged out"}, http.StatusOK)
+}
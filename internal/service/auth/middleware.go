@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+// APIKeyHeader carries the API key of service-to-service callers.
+const APIKeyHeader = "X-API-Key"
+
+// apiKeyContextKey holds the API key of a request, or nil for an unknown
+// key, once it has been looked up.
+const apiKeyContextKey = "auth.api_key"
+
+// Authenticate rejects requests without a valid bearer access token. On
+// success the user id and username are stored in the gin context under
+// "user_id" and "username", and the username becomes the actor of the
+// request context.
+func (s *Service) Authenticate(ctx *gin.Context) {
+	scheme, token, ok := strings.Cut(ctx.GetHeader("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		s.unauthorized(ctx, "missing bearer token")
+		return
+	}
+
+	var c claims
+	_, err := jwt.ParseWithClaims(token, &c, func(*jwt.Token) (interface{}, error) {
+		return s.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		s.Logger.Info("auth.Authenticate: ", zap.Error(err))
+		s.unauthorized(ctx, "invalid or expired access token")
+		return
+	}
+
+	userId, err := strconv.Atoi(c.Subject)
+	if err != nil {
+		s.unauthorized(ctx, "invalid or expired access token")
+		return
+	}
+
+	ctx.Set("user_id", userId)
+	ctx.Set("username", c.Username)
+	ctx.Request = ctx.Request.WithContext(models.WithActor(ctx.Request.Context(), c.Username))
+}
+
+// RequireAdmin admits logged in users listed in ADMIN_USERS, ignoring case.
+// Register refuses those names, so they must have been registered before they
+// were listed.
+func (s *Service) RequireAdmin(ctx *gin.Context) {
+	s.Authenticate(ctx)
+	if ctx.IsAborted() {
+		return
+	}
+
+	if !s.isAdminName(ctx.GetString("username")) {
+		respond.Error(ctx, "admin access required", http.StatusForbidden)
+		ctx.Abort()
+	}
+}
+
+// Require admits logged in users and API keys holding scope.
+func (s *Service) Require(scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.GetHeader(APIKeyHeader) != "" {
+			s.authorizeKey(ctx, scope)
+			return
+		}
+		s.Authenticate(ctx)
+	}
+}
+
+// Allow admits everybody, except API keys that do not hold scope.
+func (s *Service) Allow(scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.GetHeader(APIKeyHeader) != "" {
+			s.authorizeKey(ctx, scope)
+		}
+	}
+}
+
+// IdentifyKey looks up the API key of the request, if it sends one, so that
+// middleware running before Require and Allow can tell callers apart by
+// KeyID. It rejects nothing itself: unknown and revoked keys are turned away
+// by Require and Allow.
+func (s *Service) IdentifyKey(ctx *gin.Context) {
+	if ctx.GetHeader(APIKeyHeader) == "" {
+		return
+	}
+
+	if _, err := s.lookupKey(ctx); err != nil {
+		s.Logger.Info("auth.IdentifyKey: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		ctx.Abort()
+	}
+}
+
+// KeyID returns the id of the API key of the request when IdentifyKey found
+// it valid, that is known and not revoked.
+func KeyID(ctx *gin.Context) (int, bool) {
+	value, _ := ctx.Get(apiKeyContextKey)
+	key, _ := value.(*models.APIKey)
+	if key == nil || key.RevokedAt != nil {
+		return 0, false
+	}
+	return key.ID, true
+}
+
+// UserID returns the id of the user a request acts for: the logged in user
+// or, for a request authorised by an API key, the user who issued the key.
+func UserID(ctx *gin.Context) (int, bool) {
+	if userId, ok := ctx.Get("user_id"); ok {
+		return userId.(int), true
+	}
+
+	if _, ok := ctx.Get("api_key_id"); !ok {
+		return 0, false
+	}
+	value, _ := ctx.Get(apiKeyContextKey)
+	key, _ := value.(*models.APIKey)
+	if key == nil || key.RevokedAt != nil || key.CreatedBy == nil {
+		return 0, false
+	}
+	return *key.CreatedBy, true
+}
+
+// lookupKey returns the API key of the request, or nil for an unknown one,
+// and remembers it in the gin context.
+func (s *Service) lookupKey(ctx *gin.Context) (*models.APIKey, error) {
+	if key, ok := ctx.Get(apiKeyContextKey); ok {
+		return key.(*models.APIKey), nil
+	}
+
+	key, err := s.Keys.GetAPIKeyByHash(ctx, hashToken(ctx.GetHeader(APIKeyHeader)))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.Set(apiKeyContextKey, key)
+	return key, nil
+}
+
+// authorizeKey checks the API key of the request and stores its id in the
+// gin context under "api_key_id". The key, by its prefix, becomes the actor
+// of the request context.
+func (s *Service) authorizeKey(ctx *gin.Context, scope string) {
+	key, err := s.lookupKey(ctx)
+	if err != nil {
+		s.Logger.Info("auth.authorizeKey: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		ctx.Abort()
+		return
+	}
+
+	if key == nil {
+		respond.Error(ctx, "invalid api key", http.StatusUnauthorized)
+		ctx.Abort()
+		return
+	}
+
+	ctx.Set("api_key_id", key.ID)
+
+	if key.RevokedAt != nil {
+		respond.Error(ctx, "invalid api key", http.StatusUnauthorized)
+		ctx.Abort()
+		return
+	}
+
+	if !key.Scopes.Has(scope) {
+		respond.Error(ctx, "api key lacks scope "+scope, http.StatusForbidden)
+		ctx.Abort()
+		return
+	}
+
+	ctx.Request = ctx.Request.WithContext(models.WithActor(ctx.Request.Context(), "api-key:"+key.Prefix))
+}
+
+func (s *Service) unauthorized(ctx *gin.Context, msg string) {
+	ctx.Header("WWW-Authenticate", `Bearer realm="music-library"`)
+	respond.Error(ctx, msg, http.StatusUnauthorized)
+	ctx.Abort()
+}
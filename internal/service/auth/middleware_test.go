@@ -0,0 +1,177 @@
+package auth_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/auth"
+)
+
+func signToken(t *testing.T, method jwt.SigningMethod, key any, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return token
+}
+
+func TestAuthenticate(t *testing.T) {
+	s := newTestService()
+	tokens := register(t, s, "alice")
+	exp := time.Now().Add(time.Minute).Unix()
+
+	w, ctx := serve(http.MethodGet, "/", "", bearer(tokens.AccessToken), s.Authenticate)
+	if w.Code != http.StatusOK || ctx.IsAborted() {
+		t.Fatalf("valid token: got status %d", w.Code)
+	}
+	if ctx.GetString("username") != "alice" || ctx.GetInt("user_id") == 0 {
+		t.Errorf("valid token: got username %q, user id %d", ctx.GetString("username"), ctx.GetInt("user_id"))
+	}
+	if actor := models.ActorFrom(ctx.Request.Context()); actor != "alice" {
+		t.Errorf("valid token: got actor %q, want alice", actor)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		header http.Header
+	}{
+		{"missing", nil},
+		{"other scheme", http.Header{"Authorization": {"Basic " + tokens.AccessToken}}},
+		{"garbage", bearer("garbage")},
+		{"other secret", bearer(signToken(t, jwt.SigningMethodHS256, []byte("other"), jwt.MapClaims{"sub": "1", "exp": exp}))},
+		{"other algorithm", bearer(signToken(t, jwt.SigningMethodHS512, []byte("secret"), jwt.MapClaims{"sub": "1", "exp": exp}))},
+		{"unsigned", bearer(signToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, jwt.MapClaims{"sub": "1", "exp": exp}))},
+		{"expired", bearer(signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"sub": "1", "exp": time.Now().Add(-time.Minute).Unix()}))},
+		{"no expiry", bearer(signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"sub": "1"}))},
+		{"bad subject", bearer(signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"sub": "alice", "exp": exp}))},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w, ctx := serve(http.MethodGet, "/", "", tc.header, s.Authenticate)
+			if w.Code != http.StatusUnauthorized || !ctx.IsAborted() {
+				t.Errorf("got status %d, aborted %v, want %d", w.Code, ctx.IsAborted(), http.StatusUnauthorized)
+			}
+			if w.Header().Get("WWW-Authenticate") == "" {
+				t.Error("got no WWW-Authenticate header")
+			}
+		})
+	}
+}
+
+func TestRequireAdmin(t *testing.T) {
+	s := newTestService()
+	alice := register(t, s, "alice")
+	bob := register(t, s, "bob")
+
+	// Admins are listed after they registered.
+	s2 := newTestService("alice")
+	s2.Repo, s2.Keys = s.Repo, s.Keys
+
+	if w, ctx := serve(http.MethodGet, "/", "", bearer(alice.AccessToken), s2.RequireAdmin); w.Code != http.StatusOK || ctx.IsAborted() {
+		t.Errorf("admin: got status %d", w.Code)
+	}
+	if w, _ := serve(http.MethodGet, "/", "", bearer(bob.AccessToken), s2.RequireAdmin); w.Code != http.StatusForbidden {
+		t.Errorf("user: got status %d, want %d", w.Code, http.StatusForbidden)
+	}
+	if w, _ := serve(http.MethodGet, "/", "", nil, s2.RequireAdmin); w.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous: got status %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+
+	// Admin names are compared like reserved names at registration.
+	s3 := newTestService("ALICE")
+	s3.Repo, s3.Keys = s.Repo, s.Keys
+	if w, ctx := serve(http.MethodGet, "/", "", bearer(alice.AccessToken), s3.RequireAdmin); w.Code != http.StatusOK || ctx.IsAborted() {
+		t.Errorf("admin listed in other case: got status %d", w.Code)
+	}
+}
+
+func TestRequireAndAllow(t *testing.T) {
+	s := newTestService()
+	tokens := register(t, s, "alice")
+	reader, revoked := "mlk_reader", "mlk_revoked"
+	addKey(t, s, reader, models.ScopeSongsRead)
+	if err := s.Keys.RevokeAPIKey(t.Context(), addKey(t, s, revoked, models.ScopeSongsRead), time.Now()); err != nil {
+		t.Fatalf("RevokeAPIKey: %v", err)
+	}
+
+	key := func(secret string) http.Header { return http.Header{"X-Api-Key": {secret}} }
+
+	for _, tc := range []struct {
+		name    string
+		header  http.Header
+		require int
+		allow   int
+	}{
+		{"anonymous", nil, http.StatusUnauthorized, http.StatusOK},
+		{"user", bearer(tokens.AccessToken), http.StatusOK, http.StatusOK},
+		{"key with scope", key(reader), http.StatusOK, http.StatusOK},
+		{"unknown key", key("mlk_unknown"), http.StatusUnauthorized, http.StatusUnauthorized},
+		{"revoked key", key(revoked), http.StatusUnauthorized, http.StatusUnauthorized},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if w, _ := serve(http.MethodGet, "/", "", tc.header, s.Require(models.ScopeSongsRead)); w.Code != tc.require {
+				t.Errorf("Require: got status %d, want %d", w.Code, tc.require)
+			}
+			if w, _ := serve(http.MethodGet, "/", "", tc.header, s.Allow(models.ScopeSongsRead)); w.Code != tc.allow {
+				t.Errorf("Allow: got status %d, want %d", w.Code, tc.allow)
+			}
+		})
+	}
+
+	w, ctx := serve(http.MethodGet, "/", "", key(reader), s.Require(models.ScopeSongsWrite))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("key without scope: got status %d, want %d", w.Code, http.StatusForbidden)
+	}
+	if _, ok := ctx.Get("api_key_id"); !ok {
+		t.Error("key without scope: got no api_key_id")
+	}
+}
+
+func TestUserID(t *testing.T) {
+	s := newTestService()
+	tokens := register(t, s, "alice")
+	w, ctx := serve(http.MethodGet, "/", "", bearer(tokens.AccessToken), s.Authenticate)
+	aliceID := ctx.GetInt("user_id")
+	if w.Code != http.StatusOK || aliceID == 0 {
+		t.Fatalf("login: got status %d, user id %d", w.Code, aliceID)
+	}
+
+	orphan := "mlk_orphan"
+	addKey(t, s, orphan, models.ScopePlaylistsRead)
+	issued := "mlk_issued"
+	sum := sha256.Sum256([]byte(issued))
+	if _, err := s.Keys.AddAPIKey(t.Context(), &models.APIKey{
+		Name:      "issued",
+		Prefix:    issued[:4],
+		KeyHash:   hex.EncodeToString(sum[:]),
+		Scopes:    models.Scopes{models.ScopePlaylistsRead},
+		CreatedBy: &aliceID,
+	}); err != nil {
+		t.Fatalf("AddAPIKey: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		header http.Header
+		want   int
+		ok     bool
+	}{
+		{"user", bearer(tokens.AccessToken), aliceID, true},
+		{"key issued by a user", http.Header{"X-Api-Key": {issued}}, aliceID, true},
+		{"key of nobody", http.Header{"X-Api-Key": {orphan}}, 0, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, ctx := serve(http.MethodGet, "/", "", tc.header, s.Require(models.ScopePlaylistsRead))
+			if got, ok := auth.UserID(ctx); got != tc.want || ok != tc.ok {
+				t.Errorf("got %d, %v, want %d, %v", got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+}
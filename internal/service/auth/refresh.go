@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Refresh                 godoc
+// @Summary                Refreshing tokens
+// @Description            Exchanging a refresh token for a new access token and a new refresh token. The presented refresh token is revoked; presenting it again revokes every refresh token of the user
+// @Tags                   Auth
+// @Accept                 json
+// @Produce                json
+// @Param req              body   models.RefreshRequest true  "refresh token"
+// @Success      		   200    {object}  models.TokenResponse
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   401    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Router       		   /auth/refresh [post]
+func (s *Service) Refresh(ctx *gin.Context) {
+	var req models.RefreshRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil || req.RefreshToken == "" {
+		s.Logger.Info("auth.Refresh: unmarshal request body", zap.Error(err))
+		respond.Error(ctx, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	refreshToken, next, err := s.newRefreshToken(now)
+	if err != nil {
+		s.Logger.Info("auth.Refresh: generate refresh token", zap.Error(err))
+		respond.Error(ctx, "token refresh error", http.StatusInternalServerError)
+		return
+	}
+
+	user, err := s.Repo.RotateRefreshToken(ctx, hashToken(req.RefreshToken), next, now)
+	if err != nil {
+		s.Logger.Info("auth.Refresh: ", zap.Error(err))
+		switch {
+		case errors.Is(err, models.ErrRefreshTokenReused):
+			respond.DomainError(ctx, err, "refresh token reused, all sessions revoked", http.StatusUnauthorized)
+		case errors.Is(err, models.ErrInvalidRefreshToken):
+			respond.DomainError(ctx, err, "invalid refresh token", http.StatusUnauthorized)
+		default:
+			respond.Error(ctx, "token refresh error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	resp, err := s.issueTokens(user, refreshToken, now)
+	if err != nil {
+		s.Logger.Info("auth.Refresh: sign access token", zap.Error(err))
+		respond.Error(ctx, "token refresh error", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Register                godoc
+// @Summary                Registering a new user
+// @Description            Creating a user account that can then log in and modify the library. Names listed in ADMIN_USERS cannot be registered
+// @Tags                   Auth
+// @Accept                 json
+// @Produce                json
+// @Param req              body   models.RegisterRequest true  "user to register"
+// @Success      		   200    {object}  models.RegisterResponse
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   403    {object}  models.ErrorResponse
+// @Failure      		   409    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Router       		   /auth/register [post]
+func (s *Service) Register(ctx *gin.Context) {
+	var req models.RegisterRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		s.Logger.Info("auth.Register: unmarshal request body", zap.Error(err))
+		respond.Error(ctx, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if validationErrors := validateCredentials(&req); len(validationErrors) > 0 {
+		respond.ValidationErrors(ctx, validationErrors...)
+		return
+	}
+
+	if s.isAdminName(req.Username) {
+		respond.Error(ctx, "username is reserved", http.StatusForbidden)
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		s.Logger.Info("auth.Register: hash password", zap.Error(err))
+		respond.Error(ctx, "user register error", http.StatusInternalServerError)
+		return
+	}
+
+	userId, err := s.Repo.AddUser(ctx, req.Username, string(hash))
+	if err != nil {
+		s.Logger.Info("auth.Register: ", zap.Error(err))
+		if errors.Is(err, models.ErrUserExists) {
+			respond.DomainError(ctx, err, "user already exists", http.StatusConflict)
+		} else {
+			respond.Error(ctx, "user register error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	resp := models.RegisterResponse{
+		Message: "User successfully registered",
+		UserID:  userId,
+	}
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
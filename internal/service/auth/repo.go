@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+type Repo interface {
+	AddUser(ctx context.Context, username, passwordHash string) (int, error)
+	GetUserByName(ctx context.Context, username string) (*models.User, error)
+	AddRefreshToken(ctx context.Context, token *models.RefreshToken) error
+	// RotateRefreshToken revokes the token stored under oldHash and stores
+	// next for the same user, returning that user. Presenting a token that
+	// was already revoked revokes every token of its user.
+	RotateRefreshToken(ctx context.Context, oldHash string, next *models.RefreshToken, now time.Time) (*models.User, error)
+	RevokeRefreshToken(ctx context.Context, hash string, now time.Time) error
+}
+
+type KeyRepo interface {
+	AddAPIKey(ctx context.Context, key *models.APIKey) (int, error)
+	GetAPIKeys(ctx context.Context) ([]models.APIKey, error)
+	// GetAPIKeyByHash returns nil when no key has the given hash; revoked
+	// keys are returned with RevokedAt set.
+	GetAPIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int, now time.Time) error
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RevokeAPIKey            godoc
+// @Summary                Revoking an API key
+// @Description            Revoking an API key; requests made with it are rejected from now on. Admins only
+// @Tags                   Admin
+// @Accept                 json
+// @Produce                json
+// @Param                  id     path      integer  true  "api key id"
+// @Success      		   200    {object}  string
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   401    {object}  models.ErrorResponse
+// @Failure      		   403    {object}  models.ErrorResponse
+// @Failure      		   404    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Security     		   BearerAuth
+// @Router       		   /admin/api-keys/{id} [delete]
+func (s *Service) RevokeAPIKey(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	keyId, err := strconv.Atoi(idParam)
+	if err != nil || keyId <= 0 {
+		s.Logger.Info("auth.RevokeAPIKey: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid api key id", http.StatusBadRequest)
+		return
+	}
+
+	err = s.Keys.RevokeAPIKey(ctx, keyId, time.Now())
+	if err != nil {
+		s.Logger.Info("auth.RevokeAPIKey: ", zap.Error(err))
+		if errors.Is(err, models.ErrAPIKeyNotFound) {
+			respond.DomainError(ctx, err, "api key does not exist", http.StatusNotFound)
+		} else {
+			respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	s.Logger.Info("auth.RevokeAPIKey: key revoked", zap.Int("api_key_id", keyId), zap.Int("user_id", ctx.GetInt("user_id")))
+
+	sendSuccessResponse(ctx, "Successfully revoked", http.StatusOK)
+}
@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"crypto/rand"
+
+	"github.com/LionJr/music-library/config"
+	"go.uber.org/zap"
+)
+
+type Service struct {
+	config *config.AppConfig
+	Logger *zap.Logger
+
+	Repo Repo
+	Keys KeyRepo
+
+	secret []byte
+}
+
+func NewService(cfg *config.AppConfig, logger *zap.Logger, repo Repo, keys KeyRepo) *Service {
+	secret := []byte(cfg.Auth.JWTSecret)
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		_, _ = rand.Read(secret)
+	}
+
+	return &Service{
+		config: cfg,
+		Logger: logger,
+
+		Repo: repo,
+		Keys: keys,
+
+		secret: secret,
+	}
+}
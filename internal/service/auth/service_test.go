@@ -0,0 +1,110 @@
+package auth_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/LionJr/music-library/config"
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/repository/memory"
+	"github.com/LionJr/music-library/internal/service/auth"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func newTestService(adminUsers ...string) *auth.Service {
+	storage := memory.NewStorage()
+	cfg := &config.AppConfig{Auth: config.Auth{
+		JWTSecret:       "secret",
+		AccessTokenTTL:  time.Minute,
+		RefreshTokenTTL: time.Hour,
+		AdminUsers:      adminUsers,
+	}}
+
+	return auth.NewService(cfg, zap.NewNop(), memory.NewUserRepository(storage), memory.NewAPIKeyRepository(storage))
+}
+
+// serve sends one request through handlers and returns the recorded
+// response together with the gin context it was handled in.
+func serve(method, target, body string, header http.Header, handlers ...gin.HandlerFunc) (*httptest.ResponseRecorder, *gin.Context) {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+
+	req := httptest.NewRequest(method, target, reader)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	for _, handler := range handlers {
+		if ctx.IsAborted() {
+			break
+		}
+		handler(ctx)
+	}
+
+	return w, ctx
+}
+
+func decode[T any](t *testing.T, w *httptest.ResponseRecorder) T {
+	t.Helper()
+
+	var v T
+	if err := json.Unmarshal(w.Body.Bytes(), &v); err != nil {
+		t.Fatalf("decode response %s: %v", w.Body, err)
+	}
+	return v
+}
+
+// register registers a user and logs it in.
+func register(t *testing.T, s *auth.Service, username string) models.TokenResponse {
+	t.Helper()
+
+	body := `{"username":"` + username + `","password":"correct horse"}`
+	if w, _ := serve(http.MethodPost, "/auth/register", body, nil, s.Register); w.Code != http.StatusOK {
+		t.Fatalf("register %s: got status %d: %s", username, w.Code, w.Body)
+	}
+
+	w, _ := serve(http.MethodPost, "/auth/login", body, nil, s.Login)
+	if w.Code != http.StatusOK {
+		t.Fatalf("login %s: got status %d: %s", username, w.Code, w.Body)
+	}
+	return decode[models.TokenResponse](t, w)
+}
+
+func bearer(token string) http.Header {
+	return http.Header{"Authorization": {"Bearer " + token}}
+}
+
+// addKey stores the API key secret with scopes and returns its id.
+func addKey(t *testing.T, s *auth.Service, secret string, scopes ...string) int {
+	t.Helper()
+
+	sum := sha256.Sum256([]byte(secret))
+	id, err := s.Keys.AddAPIKey(t.Context(), &models.APIKey{
+		Name:    "test",
+		Prefix:  secret[:4],
+		KeyHash: hex.EncodeToString(sum[:]),
+		Scopes:  scopes,
+	})
+	if err != nil {
+		t.Fatalf("AddAPIKey: %v", err)
+	}
+	return id
+}
@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	minUsernameLength = 3
+	maxUsernameLength = 50
+	minPasswordLength = 8
+	// bcrypt ignores everything past 72 bytes.
+	maxPasswordLength = 72
+
+	maxAPIKeyNameLength = 100
+	apiKeyPrefix        = "mlk_"
+	// apiKeyShownLength characters of a key are stored in the clear so
+	// admins can tell keys apart.
+	apiKeyShownLength = 12
+)
+
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+type claims struct {
+	Username string `json:"username"`
+	jwt.RegisteredClaims
+}
+
+func sendSuccessResponse(ctx *gin.Context, data interface{}, status int) {
+	ctx.JSON(status, data)
+}
+
+// dummyPasswordHash is compared with the password of a login for an unknown
+// user.
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("not the password of anybody"), bcrypt.DefaultCost)
+	return hash
+})
+
+// isAdminName reports whether username is listed in ADMIN_USERS, ignoring
+// case.
+func (s *Service) isAdminName(username string) bool {
+	for _, admin := range s.config.Auth.AdminUsers {
+		if strings.EqualFold(admin, username) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateCredentials(req *models.RegisterRequest) []models.FieldError {
+	var validationErrors []models.FieldError
+
+	switch {
+	case len(req.Username) < minUsernameLength:
+		validationErrors = append(validationErrors, models.FieldError{Field: "username", Message: "username is too short"})
+	case len(req.Username) > maxUsernameLength:
+		validationErrors = append(validationErrors, models.FieldError{Field: "username", Message: "username is too long"})
+	case !usernamePattern.MatchString(req.Username):
+		validationErrors = append(validationErrors, models.FieldError{Field: "username", Message: "username may only contain letters, digits, '_', '.' and '-'"})
+	}
+
+	switch {
+	case len(req.Password) < minPasswordLength:
+		validationErrors = append(validationErrors, models.FieldError{Field: "password", Message: "password is too short"})
+	case len(req.Password) > maxPasswordLength:
+		validationErrors = append(validationErrors, models.FieldError{Field: "password", Message: "password is too long"})
+	}
+
+	return validationErrors
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// newRefreshToken returns a random refresh token and its stored form.
+func (s *Service) newRefreshToken(now time.Time) (string, *models.RefreshToken, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", nil, err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, &models.RefreshToken{
+		TokenHash: hashToken(token),
+		ExpiresAt: now.Add(s.config.Auth.RefreshTokenTTL),
+	}, nil
+}
+
+func (s *Service) newAccessToken(user *models.User, now time.Time) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		Username: user.Username,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.Itoa(user.ID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.config.Auth.AccessTokenTTL)),
+		},
+	})
+
+	return token.SignedString(s.secret)
+}
+
+// issueTokens returns a fresh access token together with the given refresh
+// token.
+func (s *Service) issueTokens(user *models.User, refreshToken string, now time.Time) (*models.TokenResponse, error) {
+	accessToken, err := s.newAccessToken(user, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TokenResponse{
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(s.config.Auth.AccessTokenTTL.Seconds()),
+		RefreshToken: refreshToken,
+	}, nil
+}
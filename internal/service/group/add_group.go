@@ -0,0 +1,60 @@
+package group
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Add                     godoc
+// @Summary                Adding a new group
+// @Description            Adding a new group if there is no group with the same name
+// @Tags                   Group
+// @Accept                 json
+// @Produce                json
+// @Param req              body   models.NewGroupRequest true  "group to add"
+// @Success      		   200    {object}  models.NewGroupResponse
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   401    {object}  models.ErrorResponse
+// @Failure      		   403    {object}  models.ErrorResponse
+// @Failure      		   409    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Security     		   BearerAuth
+// @Security     		   ApiKeyAuth
+// @Router       		   /groups [post]
+func (s *Service) Add(ctx *gin.Context) {
+	var req models.NewGroupRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		s.Logger.Info("group.Add: unmarshal request body", zap.Error(err))
+		respond.Error(ctx, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	name, validationErrors := validateName(req.Name)
+	if len(validationErrors) > 0 {
+		respond.ValidationErrors(ctx, validationErrors...)
+		return
+	}
+
+	groupId, err := s.Repo.Add(ctx, name)
+	if err != nil {
+		s.Logger.Info("group.Add: ", zap.Error(err))
+		if errors.Is(err, models.ErrGroupExists) {
+			respond.DomainError(ctx, err, "group already exists", http.StatusConflict)
+		} else {
+			respond.Error(ctx, "group add error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	resp := models.NewGroupResponse{
+		Message: "Group successfully added",
+		GroupID: groupId,
+	}
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
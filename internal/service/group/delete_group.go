@@ -0,0 +1,64 @@
+package group
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Delete godoc
+// @Summary      	     Remove group
+// @Description  	     Remove group by id, only groups without songs, counting those in the trash, and albums can be removed
+// @Tags         	     Group
+// @Accept       	     json
+// @Produce      	     json
+// @Param 			     id 	             path      integer                true   "group id"
+// @Success      	     200  		         {object}  string
+// @Failure      	     400  			     {object}  models.ErrorResponse
+// @Failure      	     401  			     {object}  models.ErrorResponse
+// @Failure      	     403  			     {object}  models.ErrorResponse
+// @Failure      	     404  			     {object}  models.ErrorResponse
+// @Failure      	     409  			     {object}  models.ErrorResponse
+// @Failure      	     500  			     {object}  models.ErrorResponse
+// @Security     	     BearerAuth
+// @Security     	     ApiKeyAuth
+// @Router       	     /groups/{id} [delete]
+func (s *Service) Delete(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	groupId, err := strconv.Atoi(idParam)
+	if err != nil || groupId <= 0 {
+		s.Logger.Info("group.Delete: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid group id", http.StatusBadRequest)
+		return
+	}
+
+	exists, err := s.Repo.GroupExists(ctx, groupId)
+	if err != nil {
+		s.Logger.Info("group.Delete: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		respond.DomainError(ctx, models.ErrGroupNotFound, "group does not exist", http.StatusNotFound)
+		return
+	}
+
+	err = s.Repo.Delete(ctx, groupId)
+	if err != nil {
+		s.Logger.Info("group.Delete: ", zap.Error(err))
+		if errors.Is(err, models.ErrGroupNotEmpty) {
+			respond.DomainError(ctx, err, "group still has songs, in the trash or not, or albums", http.StatusConflict)
+		} else {
+			respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	sendSuccessResponse(ctx, "Successfully deleted", http.StatusOK)
+}
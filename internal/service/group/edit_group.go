@@ -0,0 +1,81 @@
+package group
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Edit godoc
+// @Summary     Update group
+// @Description Rename group by id, the new name is visible on all of its songs
+// @Tags        Group
+// @Accept      json
+// @Produce     json
+// @Param       req  body     models.EditGroupRequest true "Group field(s) need to be updated"
+// @Param       id   path     integer                 true "Group id"
+// @Success     200  {object} models.SuccessResponse "Group successfully updated"
+// @Failure     400  {object} models.ErrorResponse
+// @Failure     401  {object} models.ErrorResponse
+// @Failure     403  {object} models.ErrorResponse
+// @Failure     404  {object} models.ErrorResponse
+// @Failure     409  {object} models.ErrorResponse
+// @Failure     500  {object} models.ErrorResponse
+// @Security    BearerAuth
+// @Security    ApiKeyAuth
+// @Router      /groups/{id} [patch]
+func (s *Service) Edit(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	groupId, err := strconv.Atoi(idParam)
+	if err != nil || groupId <= 0 {
+		s.Logger.Info("group.Edit: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid group id", http.StatusBadRequest)
+		return
+	}
+
+	var req models.EditGroupRequest
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		s.Logger.Info("group.Edit: unmarshal request body", zap.Error(err))
+		respond.Error(ctx, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	exists, err := s.Repo.GroupExists(ctx, groupId)
+	if err != nil {
+		s.Logger.Info("group.Edit: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		respond.DomainError(ctx, models.ErrGroupNotFound, "group does not exist", http.StatusNotFound)
+		return
+	}
+
+	if req.Name != nil {
+		name, validationErrors := validateName(*req.Name)
+		if len(validationErrors) > 0 {
+			respond.ValidationErrors(ctx, validationErrors...)
+			return
+		}
+		req.Name = &name
+	}
+
+	err = s.Repo.Edit(ctx, groupId, &req)
+	if err != nil {
+		s.Logger.Error("group.Edit", zap.Error(err))
+		if errors.Is(err, models.ErrGroupExists) {
+			respond.DomainError(ctx, err, "group already exists", http.StatusConflict)
+		} else {
+			respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	sendSuccessResponse(ctx, models.SuccessResponse{Message: "Group successfully updated"}, http.StatusOK)
+}
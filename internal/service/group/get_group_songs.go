@@ -0,0 +1,73 @@
+package group
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GetSongs                godoc
+// @Summary                Get songs of group
+// @Description            Get songs of group with pagination, default pagination value will be 3
+// @Tags                   Group
+// @Accept                 json
+// @Produce                json
+// @Param   	           id      path      int     true          "group id"
+// @Param   	           page    query     int     false         "page number in pagination"
+// @Param  		           limit   query     int     false         "number of elements in one page, at most 100"
+// @Success      		   200    {object}  models.GetGroupSongsResponse
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   404    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Router       		   /groups/{id}/songs [get]
+func (s *Service) GetSongs(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	groupId, err := strconv.Atoi(idParam)
+	if err != nil || groupId <= 0 {
+		s.Logger.Info("group.GetSongs: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid group id", http.StatusBadRequest)
+		return
+	}
+
+	exists, err := s.Repo.GroupExists(ctx, groupId)
+	if err != nil {
+		s.Logger.Info("group.GetSongs: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		respond.DomainError(ctx, models.ErrGroupNotFound, "group does not exist", http.StatusNotFound)
+		return
+	}
+
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = models.DefaultPaginationPage
+	}
+
+	limit, err := strconv.Atoi(ctx.Query("limit"))
+	if err != nil || limit < 1 {
+		limit = models.DefaultPaginationSize
+	}
+	limit = min(limit, models.MaxPaginationSize)
+
+	songs, totalSongCount, err := s.Repo.GetGroupSongs(ctx, groupId, page, limit)
+	if err != nil {
+		s.Logger.Info("group.GetSongs", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := models.GetGroupSongsResponse{
+		Songs:          songs,
+		TotalSongCount: totalSongCount,
+		Page:           page,
+	}
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
@@ -0,0 +1,90 @@
+package group
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/LionJr/music-library/internal/service/validate"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GetGroups               godoc
+// @Summary                Get groups
+// @Description            Get groups ordered by name with pagination, default pagination value will be 3
+// @Tags                   Group
+// @Accept                 json
+// @Produce                json
+// @Param   	           name    query     string  false       "case-insensitive substring of the group name"
+// @Param   	           page    query     int     false       "page number in pagination"
+// @Param  		           limit   query     int     false       "number of elements in one page, at most 100"
+// @Success      		   200    {object}  models.GetGroupsResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Router       		   /groups [get]
+func (s *Service) GetGroups(ctx *gin.Context) {
+	name := validate.Query(ctx.Query("name"))
+
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = models.DefaultPaginationPage
+	}
+
+	limit, err := strconv.Atoi(ctx.Query("limit"))
+	if err != nil || limit < 1 {
+		limit = models.DefaultPaginationSize
+	}
+	limit = min(limit, models.MaxPaginationSize)
+
+	groups, totalGroupCount, err := s.Repo.GetGroups(ctx, name, page, limit)
+	if err != nil {
+		s.Logger.Info("group.GetGroups", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := models.GetGroupsResponse{
+		Groups:          groups,
+		TotalGroupCount: totalGroupCount,
+		Page:            page,
+	}
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
+
+// GetGroup                godoc
+// @Summary                Get group
+// @Description            Get group by id
+// @Tags                   Group
+// @Accept                 json
+// @Produce                json
+// @Param   	           id      path      int     true          "group id"
+// @Success      		   200    {object}  models.Group
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   404    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Router       		   /groups/{id} [get]
+func (s *Service) GetGroup(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	groupId, err := strconv.Atoi(idParam)
+	if err != nil || groupId <= 0 {
+		s.Logger.Info("group.GetGroup: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid group id", http.StatusBadRequest)
+		return
+	}
+
+	group, err := s.Repo.GetGroup(ctx, groupId)
+	if err != nil {
+		s.Logger.Info("group.GetGroup: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if group == nil {
+		respond.DomainError(ctx, models.ErrGroupNotFound, "group does not exist", http.StatusNotFound)
+		return
+	}
+
+	sendSuccessResponse(ctx, group, http.StatusOK)
+}
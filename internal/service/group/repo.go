@@ -0,0 +1,17 @@
+package group
+
+import (
+	"context"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+type Repo interface {
+	Add(ctx context.Context, name string) (int, error)
+	Delete(ctx context.Context, id int) error
+	Edit(ctx context.Context, id int, input *models.EditGroupRequest) error
+	GetGroup(ctx context.Context, id int) (*models.Group, error)
+	GetGroups(ctx context.Context, name string, page, limit int) ([]models.Group, int, error)
+	GetGroupSongs(ctx context.Context, id, page, limit int) ([]models.Song, int, error)
+	GroupExists(ctx context.Context, id int) (bool, error)
+}
@@ -0,0 +1,21 @@
+package group
+
+import (
+	"github.com/LionJr/music-library/internal/service/validate"
+	"github.com/gin-gonic/gin"
+)
+
+const maxGroupNameLength = 100
+
+func sendSuccessResponse(ctx *gin.Context, data interface{}, status int) {
+	ctx.JSON(status, data)
+}
+
+// validateName normalises a group name and reports what is wrong with it. It
+// follows the song service, so that group names typed here and names
+// resolved when adding songs stay identical.
+func validateName(name string) (string, validate.Errors) {
+	var validationErrors validate.Errors
+	validationErrors.Name("name", "group name", &name, maxGroupNameLength)
+	return name, validationErrors
+}
@@ -0,0 +1,178 @@
+// Package pagination parses page/limit/cursor query parameters and issues
+// signed keyset cursors for the listings of every service.
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Signer turns cursors into opaque tokens of the form
+// base64(json).base64(hmac-sha256) and back. An empty secret is replaced by a
+// random one.
+type Signer struct {
+	secret []byte
+}
+
+func NewSigner(secret string) *Signer {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		_, _ = rand.Read(key)
+	}
+	return &Signer{secret: key}
+}
+
+func (c *Signer) encode(cursor *models.Cursor) string {
+	payload, _ := json.Marshal(cursor)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(c.sign(encoded))
+}
+
+func (c *Signer) decode(token string) (*models.Cursor, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, c.sign(encoded)) {
+		return nil, ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor models.Cursor
+	if err = json.Unmarshal(payload, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+func (c *Signer) sign(data string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// Request is a parsed page/limit/cursor query. Its Page asks the repository
+// for one extra row so that the presence of a further page can be detected.
+type Request struct {
+	Page   *models.Page
+	Number int
+	limit  int
+	filter string
+}
+
+// ParsePage reads the page, limit and cursor query parameters. A cursor takes
+// precedence over page and must have been issued for the same listing with
+// the same filter, which is compared by its JSON encoding; nil means none.
+func (c *Signer) ParsePage(ctx *gin.Context, scope, sortBy string, desc bool, filter any) (*Request, error) {
+	limit, err := strconv.Atoi(ctx.Query("limit"))
+	if err != nil || limit < 1 {
+		limit = models.DefaultPaginationSize
+	}
+	limit = min(limit, models.MaxPaginationSize)
+
+	filterHash, err := hashFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if token := ctx.Query("cursor"); token != "" {
+		cursor, err := c.decode(token)
+		if err != nil {
+			return nil, err
+		}
+
+		if cursor.Scope != scope || cursor.SortBy != sortBy || cursor.Desc != desc || cursor.Filter != filterHash {
+			return nil, ErrInvalidCursor
+		}
+
+		return &Request{
+			Page:   &models.Page{Limit: limit + 1, Cursor: cursor},
+			limit:  limit,
+			filter: filterHash,
+		}, nil
+	}
+
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = models.DefaultPaginationPage
+	}
+
+	return &Request{
+		Page:   &models.Page{Offset: (page - 1) * limit, Limit: limit + 1},
+		Number: page,
+		limit:  limit,
+		filter: filterHash,
+	}, nil
+}
+
+// hashFilter returns a short hash of the JSON encoding of filter, or "" for
+// nil.
+func hashFilter(filter any) (string, error) {
+	if filter == nil {
+		return "", nil
+	}
+
+	encoded, err := json.Marshal(filter)
+	if err != nil {
+		return "", fmt.Errorf("encode cursor filter: %w", err)
+	}
+
+	sum := sha256.Sum256(encoded)
+	return base64.RawURLEncoding.EncodeToString(sum[:12]), nil
+}
+
+// Paginate trims the extra row fetched by ParsePage and issues cursors for
+// the neighbouring pages. key returns the sort value and id of an item.
+func Paginate[T any](c *Signer, req *Request, items []T, scope, sortBy string, desc bool, key func(*T) (string, int)) ([]T, string, string) {
+	backward := req.Page.Cursor != nil && req.Page.Cursor.Backward
+
+	more := len(items) > req.limit
+	if more {
+		if backward {
+			items = items[len(items)-req.limit:]
+		} else {
+			items = items[:req.limit]
+		}
+	}
+
+	if len(items) == 0 {
+		return items, "", ""
+	}
+
+	hasNext, hasPrev := more, req.Page.Cursor != nil || req.Page.Offset > 0
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+
+	var next, prev string
+	if hasNext {
+		k, id := key(&items[len(items)-1])
+		next = c.encode(&models.Cursor{Scope: scope, Key: k, ID: id, SortBy: sortBy, Desc: desc, Filter: req.filter})
+	}
+	if hasPrev {
+		k, id := key(&items[0])
+		prev = c.encode(&models.Cursor{Scope: scope, Key: k, ID: id, Backward: true, SortBy: sortBy, Desc: desc, Filter: req.filter})
+	}
+
+	return items, next, prev
+}
@@ -0,0 +1,183 @@
+package pagination_test
+
+import (
+	"errors"
+	"math"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/pagination"
+)
+
+type item struct {
+	id int
+}
+
+func itemKey(i *item) (string, int) { return strconv.Itoa(i.id), i.id }
+
+type filter struct {
+	Group string
+}
+
+func newContext(query url.Values) *gin.Context {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest("GET", "/?"+query.Encode(), nil)
+	return ctx
+}
+
+func parse(t *testing.T, s *pagination.Signer, query url.Values, f any) *pagination.Request {
+	t.Helper()
+
+	req, err := s.ParsePage(newContext(query), "songs", "song_name", false, f)
+	if err != nil {
+		t.Fatalf("ParsePage: %v", err)
+	}
+	return req
+}
+
+// items returns the items with ids from through to.
+func items(from, to int) []item {
+	var out []item
+	for id := from; id <= to; id++ {
+		out = append(out, item{id})
+	}
+	return out
+}
+
+func ids(items []item) []int {
+	out := make([]int, len(items))
+	for i, it := range items {
+		out[i] = it.id
+	}
+	return out
+}
+
+func TestParsePageOffset(t *testing.T) {
+	s := pagination.NewSigner("secret")
+
+	req := parse(t, s, url.Values{"page": {"3"}, "limit": {"5"}}, nil)
+	if req.Number != 3 || req.Page.Offset != 10 || req.Page.Limit != 6 || req.Page.Cursor != nil {
+		t.Errorf("got number %d, page %+v, want number 3, offset 10, limit 6", req.Number, req.Page)
+	}
+
+	req = parse(t, s, url.Values{"page": {"-1"}, "limit": {"x"}}, nil)
+	if req.Number != models.DefaultPaginationPage || req.Page.Limit != models.DefaultPaginationSize+1 {
+		t.Errorf("got number %d, page %+v, want the defaults", req.Number, req.Page)
+	}
+
+	for _, limit := range []string{"100000000", strconv.Itoa(math.MaxInt)} {
+		req = parse(t, s, url.Values{"limit": {limit}}, nil)
+		if req.Page.Limit != models.MaxPaginationSize+1 {
+			t.Errorf("limit %s: got page limit %d, want %d", limit, req.Page.Limit, models.MaxPaginationSize+1)
+		}
+	}
+}
+
+func TestPaginateForwardAndBackward(t *testing.T) {
+	s := pagination.NewSigner("secret")
+	f := filter{Group: "Muse"}
+	limit := url.Values{"limit": {"2"}}
+
+	// First page: the repository returned one row more than asked for.
+	req := parse(t, s, limit, f)
+	page, next, prev := pagination.Paginate(s, req, items(1, 3), "songs", "song_name", false, itemKey)
+	if got := ids(page); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Fatalf("first page: got ids %v, want [1 2]", got)
+	}
+	if next == "" || prev != "" {
+		t.Fatalf("first page: got next %q, prev %q, want only next", next, prev)
+	}
+
+	req = parse(t, s, url.Values{"limit": {"2"}, "cursor": {next}}, f)
+	cursor := req.Page.Cursor
+	if cursor == nil || cursor.ID != 2 || cursor.Key != "2" || cursor.Backward {
+		t.Fatalf("next cursor: got %+v, want forward after id 2", cursor)
+	}
+
+	// Last page: no extra row.
+	page, next, prev = pagination.Paginate(s, req, items(3, 4), "songs", "song_name", false, itemKey)
+	if got := ids(page); len(got) != 2 || got[0] != 3 {
+		t.Fatalf("last page: got ids %v, want [3 4]", got)
+	}
+	if next != "" || prev == "" {
+		t.Fatalf("last page: got next %q, prev %q, want only prev", next, prev)
+	}
+
+	req = parse(t, s, url.Values{"limit": {"2"}, "cursor": {prev}}, f)
+	cursor = req.Page.Cursor
+	if cursor == nil || cursor.ID != 3 || !cursor.Backward {
+		t.Fatalf("prev cursor: got %+v, want backward before id 3", cursor)
+	}
+
+	// Going back: the rows before the cursor, with one extra at the start.
+	page, next, prev = pagination.Paginate(s, req, items(0, 2), "songs", "song_name", false, itemKey)
+	if got := ids(page); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Fatalf("backward page: got ids %v, want [1 2]", got)
+	}
+	if next == "" || prev == "" {
+		t.Errorf("backward page: got next %q, prev %q, want both", next, prev)
+	}
+
+	// Going back to the first page.
+	page, _, prev = pagination.Paginate(s, req, items(1, 2), "songs", "song_name", false, itemKey)
+	if len(page) != 2 || prev != "" {
+		t.Errorf("backward to the start: got %d items, prev %q, want 2 items and no prev", len(page), prev)
+	}
+}
+
+func TestPaginateEmpty(t *testing.T) {
+	s := pagination.NewSigner("secret")
+
+	page, next, prev := pagination.Paginate(s, parse(t, s, nil, nil), nil, "songs", "song_name", false, itemKey)
+	if len(page) != 0 || next != "" || prev != "" {
+		t.Errorf("got %d items, next %q, prev %q, want nothing", len(page), next, prev)
+	}
+}
+
+func TestParsePageRejectsCursor(t *testing.T) {
+	s := pagination.NewSigner("secret")
+	f := filter{Group: "Muse"}
+
+	_, next, _ := pagination.Paginate(s, parse(t, s, url.Values{"limit": {"1"}}, f), items(1, 2), "songs", "song_name", false, itemKey)
+	payload, signature, _ := strings.Cut(next, ".")
+
+	tampered := []byte(payload)
+	tampered[len(tampered)/2] ^= 1
+
+	for _, tc := range []struct {
+		name   string
+		signer *pagination.Signer
+		cursor string
+		scope  string
+		sortBy string
+		desc   bool
+		filter any
+	}{
+		{"garbage", s, "garbage", "songs", "song_name", false, f},
+		{"tampered payload", s, string(tampered) + "." + signature, "songs", "song_name", false, f},
+		{"tampered signature", s, payload + "." + signature[1:], "songs", "song_name", false, f},
+		{"other secret", pagination.NewSigner("other"), next, "songs", "song_name", false, f},
+		{"other scope", s, next, "verses:1", "song_name", false, f},
+		{"other sorting", s, next, "songs", "release_date", false, f},
+		{"other direction", s, next, "songs", "song_name", true, f},
+		{"other filter", s, next, "songs", "song_name", false, filter{Group: "Queen"}},
+		{"no filter", s, next, "songs", "song_name", false, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := newContext(url.Values{"cursor": {tc.cursor}})
+			if _, err := tc.signer.ParsePage(ctx, tc.scope, tc.sortBy, tc.desc, tc.filter); !errors.Is(err, pagination.ErrInvalidCursor) {
+				t.Errorf("got error %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+
+	if _, err := s.ParsePage(newContext(url.Values{"cursor": {next}}), "songs", "song_name", false, filter{Group: "Muse"}); err != nil {
+		t.Errorf("same filter: got error %v, want none", err)
+	}
+}
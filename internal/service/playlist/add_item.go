@@ -0,0 +1,86 @@
+package playlist
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AddItem                 godoc
+// @Summary                Add song to playlist
+// @Description            Append song to the end of playlist, the same song may be added more than once
+// @Tags                   Playlist
+// @Accept                 json
+// @Produce                json
+// @Param   	           id     path   int                           true  "playlist id"
+// @Param req              body   models.AddPlaylistItemRequest true  "song to add"
+// @Success      		   200    {object}  models.AddPlaylistItemResponse
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   401    {object}  models.ErrorResponse
+// @Failure      		   403    {object}  models.ErrorResponse
+// @Failure      		   404    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Security     		   BearerAuth
+// @Security     		   ApiKeyAuth
+// @Router       		   /playlists/{id}/items [post]
+func (s *Service) AddItem(ctx *gin.Context) {
+	userId, ok := owner(ctx)
+	if !ok {
+		return
+	}
+
+	idParam := ctx.Param("id")
+	playlistId, err := strconv.Atoi(idParam)
+	if err != nil || playlistId <= 0 {
+		s.Logger.Info("playlist.AddItem: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid playlist id", http.StatusBadRequest)
+		return
+	}
+
+	var req models.AddPlaylistItemRequest
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		s.Logger.Info("playlist.AddItem: unmarshal request body", zap.Error(err))
+		respond.Error(ctx, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.SongID <= 0 {
+		respond.Error(ctx, "invalid song id", http.StatusBadRequest)
+		return
+	}
+
+	exists, err := s.Repo.PlaylistExists(ctx, userId, playlistId)
+	if err != nil {
+		s.Logger.Info("playlist.AddItem: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		respond.DomainError(ctx, models.ErrPlaylistNotFound, "playlist does not exist", http.StatusNotFound)
+		return
+	}
+
+	position, err := s.Repo.AddItem(ctx, playlistId, req.SongID)
+	if err != nil {
+		s.Logger.Info("playlist.AddItem: ", zap.Error(err))
+		if errors.Is(err, models.ErrSongNotFound) {
+			respond.DomainError(ctx, err, "song does not exist", http.StatusNotFound)
+		} else {
+			respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	resp := models.AddPlaylistItemResponse{
+		Message:  "Song successfully added to playlist",
+		Position: position,
+	}
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
@@ -0,0 +1,59 @@
+package playlist
+
+import (
+	"net/http"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Add                     godoc
+// @Summary                Adding a new playlist
+// @Description            Adding a new empty playlist
+// @Tags                   Playlist
+// @Accept                 json
+// @Produce                json
+// @Param req              body   models.NewPlaylistRequest true  "playlist to add"
+// @Success      		   200    {object}  models.NewPlaylistResponse
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   401    {object}  models.ErrorResponse
+// @Failure      		   403    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Security     		   BearerAuth
+// @Security     		   ApiKeyAuth
+// @Router       		   /playlists [post]
+func (s *Service) Add(ctx *gin.Context) {
+	userId, ok := owner(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.NewPlaylistRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		s.Logger.Info("playlist.Add: unmarshal request body", zap.Error(err))
+		respond.Error(ctx, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	name, validationErrors := validateName(req.Name)
+	if len(validationErrors) > 0 {
+		respond.ValidationErrors(ctx, validationErrors...)
+		return
+	}
+
+	playlistId, err := s.Repo.Add(ctx, userId, name)
+	if err != nil {
+		s.Logger.Info("playlist.Add: ", zap.Error(err))
+		respond.Error(ctx, "playlist add error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := models.NewPlaylistResponse{
+		Message:    "Playlist successfully added",
+		PlaylistID: playlistId,
+	}
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
@@ -0,0 +1,63 @@
+package playlist
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Delete godoc
+// @Summary      	     Remove playlist
+// @Description  	     Remove playlist with all of its items by id, the songs are kept
+// @Tags         	     Playlist
+// @Accept       	     json
+// @Produce      	     json
+// @Param 			     id 	             path      integer                true   "playlist id"
+// @Success      	     200  		         {object}  string
+// @Failure      	     400  			     {object}  models.ErrorResponse
+// @Failure      	     401  			     {object}  models.ErrorResponse
+// @Failure      	     403  			     {object}  models.ErrorResponse
+// @Failure      	     404  			     {object}  models.ErrorResponse
+// @Failure      	     500  			     {object}  models.ErrorResponse
+// @Security     	     BearerAuth
+// @Security     	     ApiKeyAuth
+// @Router       	     /playlists/{id} [delete]
+func (s *Service) Delete(ctx *gin.Context) {
+	userId, ok := owner(ctx)
+	if !ok {
+		return
+	}
+
+	idParam := ctx.Param("id")
+	playlistId, err := strconv.Atoi(idParam)
+	if err != nil || playlistId <= 0 {
+		s.Logger.Info("playlist.Delete: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid playlist id", http.StatusBadRequest)
+		return
+	}
+
+	exists, err := s.Repo.PlaylistExists(ctx, userId, playlistId)
+	if err != nil {
+		s.Logger.Info("playlist.Delete: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		respond.DomainError(ctx, models.ErrPlaylistNotFound, "playlist does not exist", http.StatusNotFound)
+		return
+	}
+
+	err = s.Repo.Delete(ctx, playlistId)
+	if err != nil {
+		s.Logger.Info("playlist.Delete: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccessResponse(ctx, "Successfully deleted", http.StatusOK)
+}
@@ -0,0 +1,80 @@
+package playlist
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Edit godoc
+// @Summary     Rename playlist
+// @Description Rename playlist by id
+// @Tags        Playlist
+// @Accept      json
+// @Produce     json
+// @Param       req  body     models.EditPlaylistRequest true "Playlist field(s) need to be updated"
+// @Param       id   path     integer                    true "Playlist id"
+// @Success     200  {object} models.SuccessResponse "Playlist successfully updated"
+// @Failure     400  {object} models.ErrorResponse
+// @Failure     401  {object} models.ErrorResponse
+// @Failure     403  {object} models.ErrorResponse
+// @Failure     404  {object} models.ErrorResponse
+// @Failure     500  {object} models.ErrorResponse
+// @Security    BearerAuth
+// @Security    ApiKeyAuth
+// @Router      /playlists/{id} [patch]
+func (s *Service) Edit(ctx *gin.Context) {
+	userId, ok := owner(ctx)
+	if !ok {
+		return
+	}
+
+	idParam := ctx.Param("id")
+	playlistId, err := strconv.Atoi(idParam)
+	if err != nil || playlistId <= 0 {
+		s.Logger.Info("playlist.Edit: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid playlist id", http.StatusBadRequest)
+		return
+	}
+
+	var req models.EditPlaylistRequest
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		s.Logger.Info("playlist.Edit: unmarshal request body", zap.Error(err))
+		respond.Error(ctx, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	exists, err := s.Repo.PlaylistExists(ctx, userId, playlistId)
+	if err != nil {
+		s.Logger.Info("playlist.Edit: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		respond.DomainError(ctx, models.ErrPlaylistNotFound, "playlist does not exist", http.StatusNotFound)
+		return
+	}
+
+	if req.Name != nil {
+		name, validationErrors := validateName(*req.Name)
+		if len(validationErrors) > 0 {
+			respond.ValidationErrors(ctx, validationErrors...)
+			return
+		}
+		req.Name = &name
+	}
+
+	err = s.Repo.Edit(ctx, playlistId, &req)
+	if err != nil {
+		s.Logger.Error("playlist.Edit", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccessResponse(ctx, models.SuccessResponse{Message: "Playlist successfully updated"}, http.StatusOK)
+}
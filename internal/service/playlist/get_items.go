@@ -0,0 +1,90 @@
+package playlist
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/pagination"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GetItems                godoc
+// @Summary                Get playlist items
+// @Description            Get songs of playlist in playlist order with pagination, default pagination value will be 3
+// @Tags                   Playlist
+// @Accept                 json
+// @Produce                json
+// @Param   	           id      path      int     true          "playlist id"
+// @Param   	           page    query     int     false         "page number in pagination, ignored when cursor is set"
+// @Param   	           cursor  query     string  false         "next_cursor or prev_cursor from a previous response"
+// @Param  		           limit   query     int     false         "number of elements in one page, at most 100"
+// @Success      		   200    {object}  models.GetPlaylistItemsResponse
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   404    {object}  models.ErrorResponse
+// @Failure      		   401    {object}  models.ErrorResponse
+// @Failure      		   403    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Security     		   BearerAuth
+// @Security     		   ApiKeyAuth
+// @Router       		   /playlists/{id}/items [get]
+func (s *Service) GetItems(ctx *gin.Context) {
+	userId, ok := owner(ctx)
+	if !ok {
+		return
+	}
+
+	idParam := ctx.Param("id")
+	playlistId, err := strconv.Atoi(idParam)
+	if err != nil || playlistId <= 0 {
+		s.Logger.Info("playlist.GetItems: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid playlist id", http.StatusBadRequest)
+		return
+	}
+
+	exists, err := s.Repo.PlaylistExists(ctx, userId, playlistId)
+	if err != nil {
+		s.Logger.Info("playlist.GetItems: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		respond.DomainError(ctx, models.ErrPlaylistNotFound, "playlist does not exist", http.StatusNotFound)
+		return
+	}
+
+	scope := itemsCursorScope(playlistId)
+	req, err := s.cursors.ParsePage(ctx, scope, "", false, nil)
+	if err != nil {
+		respond.Error(ctx, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	items, totalItemCount, err := s.Repo.GetItems(ctx, playlistId, req.Page)
+	if err != nil {
+		s.Logger.Info("playlist.GetItems", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	items, next, prev := pagination.Paginate(s.cursors, req, items, scope, "", false,
+		func(item *models.PlaylistItem) (string, int) { return strconv.Itoa(item.SortKey), item.ItemID },
+	)
+
+	resp := models.GetPlaylistItemsResponse{
+		Items:          items,
+		TotalItemCount: totalItemCount,
+		Page:           req.Number,
+		NextCursor:     next,
+		PrevCursor:     prev,
+	}
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
+
+func itemsCursorScope(playlistId int) string {
+	return "playlist:" + strconv.Itoa(playlistId)
+}
@@ -0,0 +1,104 @@
+package playlist
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GetPlaylists            godoc
+// @Summary                Get playlists
+// @Description            Get the playlists of the user with pagination, default pagination value will be 3
+// @Tags                   Playlist
+// @Accept                 json
+// @Produce                json
+// @Param   	           page    query     int     false       "page number in pagination"
+// @Param  		           limit   query     int     false       "number of elements in one page, at most 100"
+// @Success      		   200    {object}  models.GetPlaylistsResponse
+// @Failure      		   401    {object}  models.ErrorResponse
+// @Failure      		   403    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Security     		   BearerAuth
+// @Security     		   ApiKeyAuth
+// @Router       		   /playlists [get]
+func (s *Service) GetPlaylists(ctx *gin.Context) {
+	userId, ok := owner(ctx)
+	if !ok {
+		return
+	}
+
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = models.DefaultPaginationPage
+	}
+
+	limit, err := strconv.Atoi(ctx.Query("limit"))
+	if err != nil || limit < 1 {
+		limit = models.DefaultPaginationSize
+	}
+	limit = min(limit, models.MaxPaginationSize)
+
+	playlists, totalPlaylistCount, err := s.Repo.GetPlaylists(ctx, userId, page, limit)
+	if err != nil {
+		s.Logger.Info("playlist.GetPlaylists", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := models.GetPlaylistsResponse{
+		Playlists:          playlists,
+		TotalPlaylistCount: totalPlaylistCount,
+		Page:               page,
+	}
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
+
+// GetPlaylist             godoc
+// @Summary                Get playlist
+// @Description            Get playlist by id, its songs are listed by GET /playlists/{id}/items
+// @Tags                   Playlist
+// @Accept                 json
+// @Produce                json
+// @Param   	           id      path      int     true          "playlist id"
+// @Success      		   200    {object}  models.Playlist
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   404    {object}  models.ErrorResponse
+// @Failure      		   401    {object}  models.ErrorResponse
+// @Failure      		   403    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Security     		   BearerAuth
+// @Security     		   ApiKeyAuth
+// @Router       		   /playlists/{id} [get]
+func (s *Service) GetPlaylist(ctx *gin.Context) {
+	userId, ok := owner(ctx)
+	if !ok {
+		return
+	}
+
+	idParam := ctx.Param("id")
+	playlistId, err := strconv.Atoi(idParam)
+	if err != nil || playlistId <= 0 {
+		s.Logger.Info("playlist.GetPlaylist: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid playlist id", http.StatusBadRequest)
+		return
+	}
+
+	playlist, err := s.Repo.GetPlaylist(ctx, userId, playlistId)
+	if err != nil {
+		s.Logger.Info("playlist.GetPlaylist: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if playlist == nil {
+		respond.DomainError(ctx, models.ErrPlaylistNotFound, "playlist does not exist", http.StatusNotFound)
+		return
+	}
+
+	sendSuccessResponse(ctx, playlist, http.StatusOK)
+}
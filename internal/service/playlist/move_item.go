@@ -0,0 +1,81 @@
+package playlist
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// MoveItem godoc
+// @Summary     Reorder playlist
+// @Description Move the item at position from to position to, the items in between shift by one
+// @Tags        Playlist
+// @Accept      json
+// @Produce     json
+// @Param       req  body     models.MovePlaylistItemRequest true "positions, starting from 1"
+// @Param       id   path     integer                        true "Playlist id"
+// @Success     200  {object} models.SuccessResponse "Playlist successfully reordered"
+// @Failure     400  {object} models.ErrorResponse
+// @Failure     401  {object} models.ErrorResponse
+// @Failure     403  {object} models.ErrorResponse
+// @Failure     404  {object} models.ErrorResponse
+// @Failure     500  {object} models.ErrorResponse
+// @Security    BearerAuth
+// @Security    ApiKeyAuth
+// @Router      /playlists/{id}/items/move [post]
+func (s *Service) MoveItem(ctx *gin.Context) {
+	userId, ok := owner(ctx)
+	if !ok {
+		return
+	}
+
+	idParam := ctx.Param("id")
+	playlistId, err := strconv.Atoi(idParam)
+	if err != nil || playlistId <= 0 {
+		s.Logger.Info("playlist.MoveItem: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid playlist id", http.StatusBadRequest)
+		return
+	}
+
+	var req models.MovePlaylistItemRequest
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		s.Logger.Info("playlist.MoveItem: unmarshal request body", zap.Error(err))
+		respond.Error(ctx, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.From <= 0 || req.To <= 0 {
+		respond.Error(ctx, "invalid position", http.StatusBadRequest)
+		return
+	}
+
+	exists, err := s.Repo.PlaylistExists(ctx, userId, playlistId)
+	if err != nil {
+		s.Logger.Info("playlist.MoveItem: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		respond.DomainError(ctx, models.ErrPlaylistNotFound, "playlist does not exist", http.StatusNotFound)
+		return
+	}
+
+	err = s.Repo.MoveItem(ctx, playlistId, req.From, req.To)
+	if err != nil {
+		s.Logger.Error("playlist.MoveItem", zap.Error(err))
+		if errors.Is(err, models.ErrPlaylistItemNotFound) {
+			respond.DomainError(ctx, err, "no item at provided position", http.StatusNotFound)
+		} else {
+			respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	sendSuccessResponse(ctx, models.SuccessResponse{Message: "Playlist successfully reordered"}, http.StatusOK)
+}
@@ -0,0 +1,77 @@
+package playlist
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RemoveItem godoc
+// @Summary      	     Remove song from playlist
+// @Description  	     Remove the item at position, the items after it move up by one
+// @Tags         	     Playlist
+// @Accept       	     json
+// @Produce      	     json
+// @Param 			     id 	             path      integer                true   "playlist id"
+// @Param 			     position            path      integer                true   "item position, starting from 1"
+// @Success      	     200  		         {object}  string
+// @Failure      	     400  			     {object}  models.ErrorResponse
+// @Failure      	     401  			     {object}  models.ErrorResponse
+// @Failure      	     403  			     {object}  models.ErrorResponse
+// @Failure      	     404  			     {object}  models.ErrorResponse
+// @Failure      	     500  			     {object}  models.ErrorResponse
+// @Security     	     BearerAuth
+// @Security     	     ApiKeyAuth
+// @Router       	     /playlists/{id}/items/{position} [delete]
+func (s *Service) RemoveItem(ctx *gin.Context) {
+	userId, ok := owner(ctx)
+	if !ok {
+		return
+	}
+
+	idParam := ctx.Param("id")
+	playlistId, err := strconv.Atoi(idParam)
+	if err != nil || playlistId <= 0 {
+		s.Logger.Info("playlist.RemoveItem: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid playlist id", http.StatusBadRequest)
+		return
+	}
+
+	positionParam := ctx.Param("position")
+	position, err := strconv.Atoi(positionParam)
+	if err != nil || position <= 0 {
+		s.Logger.Info("playlist.RemoveItem: ", zap.String("position", positionParam))
+		respond.Error(ctx, "invalid position", http.StatusBadRequest)
+		return
+	}
+
+	exists, err := s.Repo.PlaylistExists(ctx, userId, playlistId)
+	if err != nil {
+		s.Logger.Info("playlist.RemoveItem: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		respond.DomainError(ctx, models.ErrPlaylistNotFound, "playlist does not exist", http.StatusNotFound)
+		return
+	}
+
+	err = s.Repo.RemoveItem(ctx, playlistId, position)
+	if err != nil {
+		s.Logger.Info("playlist.RemoveItem: ", zap.Error(err))
+		if errors.Is(err, models.ErrPlaylistItemNotFound) {
+			respond.DomainError(ctx, err, "no item at provided position", http.StatusNotFound)
+		} else {
+			respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	sendSuccessResponse(ctx, "Successfully deleted", http.StatusOK)
+}
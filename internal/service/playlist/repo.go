@@ -0,0 +1,30 @@
+package playlist
+
+import (
+	"context"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+// Repo stores playlists. Item positions are 1-based and contiguous. Items of
+// songs in the trash are hidden and skipped by positions, but keep their
+// places for a restore.
+//
+// Playlists belong to users. The methods taking a user id see only the
+// playlists of that user; the others take a playlist whose owner has been
+// checked with PlaylistExists.
+type Repo interface {
+	Add(ctx context.Context, userId int, name string) (int, error)
+	Delete(ctx context.Context, id int) error
+	Edit(ctx context.Context, id int, input *models.EditPlaylistRequest) error
+	GetPlaylist(ctx context.Context, userId, id int) (*models.Playlist, error)
+	GetPlaylists(ctx context.Context, userId, page, limit int) ([]models.Playlist, int, error)
+	// AddItem appends the song and returns its position.
+	AddItem(ctx context.Context, playlistId, songId int) (int, error)
+	RemoveItem(ctx context.Context, playlistId, position int) error
+	// MoveItem moves the item at from to position to, shifting the items in
+	// between, in a single transaction.
+	MoveItem(ctx context.Context, playlistId, from, to int) error
+	GetItems(ctx context.Context, playlistId int, page *models.Page) ([]models.PlaylistItem, int, error)
+	PlaylistExists(ctx context.Context, userId, id int) (bool, error)
+}
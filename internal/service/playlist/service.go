@@ -0,0 +1,27 @@
+package playlist
+
+import (
+	"github.com/LionJr/music-library/config"
+	"github.com/LionJr/music-library/internal/service/pagination"
+	"go.uber.org/zap"
+)
+
+type Service struct {
+	config *config.AppConfig
+	Logger *zap.Logger
+
+	Repo Repo
+
+	cursors *pagination.Signer
+}
+
+func NewService(cfg *config.AppConfig, logger *zap.Logger, repo Repo) *Service {
+	return &Service{
+		config: cfg,
+		Logger: logger,
+
+		Repo: repo,
+
+		cursors: pagination.NewSigner(cfg.Pagination.CursorSecret),
+	}
+}
@@ -0,0 +1,34 @@
+package playlist
+
+import (
+	"net/http"
+
+	"github.com/LionJr/music-library/internal/service/auth"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/LionJr/music-library/internal/service/validate"
+	"github.com/gin-gonic/gin"
+)
+
+const maxPlaylistNameLength = 200
+
+func sendSuccessResponse(ctx *gin.Context, data interface{}, status int) {
+	ctx.JSON(status, data)
+}
+
+// owner returns the user whose playlists a request works on. It answers
+// requests that act for no user, such as those with an API key of a deleted
+// user, with 403.
+func owner(ctx *gin.Context) (int, bool) {
+	userId, ok := auth.UserID(ctx)
+	if !ok {
+		respond.Error(ctx, "playlists belong to users", http.StatusForbidden)
+	}
+	return userId, ok
+}
+
+// validateName normalises a playlist name and reports what is wrong with it.
+func validateName(name string) (string, validate.Errors) {
+	var validationErrors validate.Errors
+	validationErrors.Name("name", "playlist name", &name, maxPlaylistNameLength)
+	return name, validationErrors
+}
@@ -0,0 +1,39 @@
+// Package respond writes the error responses of the services, all in the
+// shape of models.ErrorResponse.
+package respond
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+// Error answers a request with an error response whose code follows from
+// status.
+func Error(ctx *gin.Context, msg string, status int) {
+	ctx.JSON(status, models.NewErrorResponse(status, msg, requestID(ctx)))
+}
+
+// DomainError answers a request with an error response for err, one of the
+// domain errors of models, with the code of err instead of the code of status.
+func DomainError(ctx *gin.Context, err error, msg string, status int) {
+	resp := models.NewErrorResponse(status, msg, requestID(ctx))
+	if code, ok := models.DomainErrorCode(err); ok {
+		resp.Code = code
+	}
+
+	ctx.JSON(status, resp)
+}
+
+// ValidationErrors answers a request with invalid fields.
+func ValidationErrors(ctx *gin.Context, details ...models.FieldError) {
+	resp := models.NewErrorResponse(http.StatusBadRequest, "", requestID(ctx), details...)
+
+	ctx.JSON(http.StatusBadRequest, resp)
+}
+
+func requestID(ctx *gin.Context) string {
+	return ctx.Writer.Header().Get(models.RequestIDHeader)
+}
@@ -1,11 +1,16 @@
 package song
 
 import (
-	"encoding/json"
-	"fmt"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/provider/songinfo"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/LionJr/music-library/internal/service/validate"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -19,45 +24,94 @@ import (
 // @Param req              body   models.NewSongRequest true  "song information to add"
 // @Success      		   200    {object}  models.NewSongResponse
 // @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   401    {object}  models.ErrorResponse
+// @Failure      		   403    {object}  models.ErrorResponse
+// @Failure      		   404    {object}  models.ErrorResponse "song info provider does not know the song"
 // @Failure      		   409    {object}  models.ErrorResponse
 // @Failure      		   500    {object}  models.ErrorResponse
+// @Failure      		   503    {object}  models.ErrorResponse "song info provider is unavailable"
+// @Header       		   503    {integer} Retry-After "seconds until the provider is tried again"
+// @Security     		   BearerAuth
+// @Security     		   ApiKeyAuth
 // @Router       		   /songs [post]
 func (s *Service) Add(ctx *gin.Context) {
 	var req models.NewSongRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		s.Logger.Info("song.Add: unmarshal request body", zap.Error(err))
-		sendErrorResponse(ctx, "invalid request body", http.StatusBadRequest)
+		respond.Error(ctx, "invalid request body", http.StatusBadRequest)
 		return
 	}
 
-	groupName := sanitizeForSQL(req.GroupName)
-	songName := sanitizeForSQL(req.SongName)
-
-	apiURL := fmt.Sprintf("%s?group=%s&song=%s", s.config.ExternalAPI.URL, groupName, songName)
-	songInfoResp, err := http.Get(apiURL)
-	if err != nil || songInfoResp.StatusCode != http.StatusOK {
-		s.Logger.Info("song.Add: fetch song details error", zap.Error(err), zap.Int("status_code", songInfoResp.StatusCode))
-		sendErrorResponse(ctx, "internal server error", http.StatusInternalServerError)
+	var validationErrors validate.Errors
+	validationErrors.Name("group", "group name", &req.GroupName, maxGroupNameLength)
+	validationErrors.Name("song", "song name", &req.SongName, maxSongNameLength)
+	if len(validationErrors) > 0 {
+		respond.ValidationErrors(ctx, validationErrors...)
 		return
 	}
-	defer songInfoResp.Body.Close()
+	groupName, songName := req.GroupName, req.SongName
+
+	detail, err := s.Metadata.FetchSongDetail(ctx.Request.Context(), groupName, songName)
+	if err != nil {
+		s.Logger.Info("song.Add: fetch song details error", zap.Error(err))
 
-	var song models.Song
-	if err = json.NewDecoder(songInfoResp.Body).Decode(&song); err != nil {
-		s.Logger.Info("song.Add: parse api response error", zap.Error(err))
-		sendErrorResponse(ctx, "internal server error", http.StatusInternalServerError)
+		var open *songinfo.CircuitOpenError
+		switch {
+		case errors.Is(err, songinfo.ErrNotFound):
+			respond.Error(ctx, "song info not found", http.StatusNotFound)
+		case errors.As(err, &open):
+			ctx.Header("Retry-After", strconv.Itoa(max(int(math.Ceil(open.RetryAfter.Seconds())), 1)))
+			respond.Error(ctx, "song info service is unavailable", http.StatusServiceUnavailable)
+		default:
+			respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		}
 		return
 	}
 
-	song.GroupName, song.SongName = groupName, songName
+	// The song is added without a release date when the external API sends
+	// one that cannot be read.
+	var releaseDate models.Date
+	if detail.ReleaseDate != "" {
+		parsed, err := parseReleaseDate(strings.TrimSpace(detail.ReleaseDate))
+		if err != nil {
+			s.Logger.Warn("song.Add: invalid release date", zap.String("release_date", detail.ReleaseDate))
+		} else {
+			releaseDate = models.Date(parsed.Format(models.DateLayout))
+		}
+	}
+
+	// Likewise without a link or text that would be rejected from a client.
+	var linkErrors, textErrors validate.Errors
+	link, text := detail.Link, detail.Text
+	if link != "" {
+		linkErrors.Link("link", "link", link, maxLinkLength)
+	}
+	if len(linkErrors) > 0 {
+		s.Logger.Warn("song.Add: invalid link", zap.String("link", link))
+		link = ""
+	}
+
+	textErrors.Text("text", "song text", &text, false)
+	if len(textErrors) > 0 {
+		s.Logger.Warn("song.Add: invalid text", zap.String("error", textErrors[0].Message))
+		text = ""
+	}
+
+	song := models.Song{
+		GroupName:   groupName,
+		SongName:    songName,
+		ReleaseDate: releaseDate,
+		Link:        link,
+		Text:        text,
+	}
 
 	songId, err := s.Repo.Add(ctx, &song)
 	if err != nil {
 		s.Logger.Info("song.Add: ", zap.Error(err))
-		if err.Error() == "song already exists" {
-			sendErrorResponse(ctx, "song already exists", http.StatusConflict)
+		if errors.Is(err, models.ErrDuplicate) {
+			respond.DomainError(ctx, err, "song already exists", http.StatusConflict)
 		} else {
-			sendErrorResponse(ctx, "song add error", http.StatusInternalServerError)
+			respond.Error(ctx, "song add error", http.StatusInternalServerError)
 		}
 		return
 	}
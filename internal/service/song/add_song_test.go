@@ -0,0 +1,120 @@
+package song_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/provider/songinfo"
+)
+
+func TestAdd(t *testing.T) {
+	metadata := &fakeMetadata{detail: &models.SongDetail{
+		ReleaseDate: "16.07.2006",
+		Text:        "Ooh baby, don't you know I suffer?",
+		Link:        "https://www.youtube.com/watch?v=Xsp3_a-PMTw",
+	}}
+	s := newTestService(metadata)
+	body := `{"group":"Muse","song":"Supermassive Black Hole"}`
+
+	w := serve(s.Add, http.MethodPost, "/songs", body, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	var resp models.NewSongResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	got, err := s.Repo.GetSong(t.Context(), resp.SongID)
+	if err != nil {
+		t.Fatalf("GetSong: %v", err)
+	}
+	if got.ReleaseDate != "2006-07-16" || got.Link != metadata.detail.Link || got.Text != metadata.detail.Text {
+		t.Errorf("got song %+v, want the provider's details", got)
+	}
+
+	w = serve(s.Add, http.MethodPost, "/songs", body, nil)
+	if w.Code != http.StatusConflict {
+		t.Errorf("duplicate: got status %d, want %d", w.Code, http.StatusConflict)
+	}
+}
+
+func TestAddProviderErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		err        error
+		status     int
+		code       string
+		retryAfter string
+	}{
+		{"not found", songinfo.ErrNotFound, http.StatusNotFound, models.CodeNotFound, ""},
+		{"circuit open", &songinfo.CircuitOpenError{RetryAfter: 1500 * time.Millisecond}, http.StatusServiceUnavailable, models.CodeUnavailable, "2"},
+		{"circuit about to close", &songinfo.CircuitOpenError{}, http.StatusServiceUnavailable, models.CodeUnavailable, "1"},
+		{"other", errors.New("connection refused"), http.StatusInternalServerError, models.CodeInternal, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newTestService(&fakeMetadata{err: tc.err})
+
+			w := serve(s.Add, http.MethodPost, "/songs", `{"group":"Muse","song":"Uprising"}`, nil)
+			if w.Code != tc.status {
+				t.Fatalf("got status %d, want %d", w.Code, tc.status)
+			}
+
+			var resp models.ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Code != tc.code {
+				t.Errorf("got code %q, want %q", resp.Code, tc.code)
+			}
+			if got := w.Header().Get("Retry-After"); got != tc.retryAfter {
+				t.Errorf("got Retry-After %q, want %q", got, tc.retryAfter)
+			}
+		})
+	}
+}
+
+func TestAddChecksProviderDetails(t *testing.T) {
+	longLink := "https://example.com/" + strings.Repeat("é", 235)
+
+	for _, tc := range []struct {
+		name     string
+		detail   models.SongDetail
+		wantLink string
+		wantText string
+	}{
+		{"link of 255 characters", models.SongDetail{Link: longLink}, longLink, ""},
+		{"link too long", models.SongDetail{Link: longLink + "é"}, "", ""},
+		{"not a web link", models.SongDetail{Link: "javascript:alert(1)"}, "", ""},
+		{"line breaks", models.SongDetail{Text: "Ooh baby\r\ndon't you know\rI suffer?"}, "", "Ooh baby\ndon't you know\nI suffer?"},
+		{"decomposed", models.SongDetail{Text: "Beyonce\u0301"}, "", "Beyonc\u00e9"},
+		{"control character", models.SongDetail{Text: "Ooh\x00baby"}, "", ""},
+		{"invalid UTF-8", models.SongDetail{Text: "Ooh \xff baby"}, "", ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newTestService(&fakeMetadata{detail: &tc.detail})
+
+			w := serve(s.Add, http.MethodPost, "/songs", `{"group":"Muse","song":"Uprising"}`, nil)
+			if w.Code != http.StatusOK {
+				t.Fatalf("got status %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+			}
+
+			var resp models.NewSongResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			got, err := s.Repo.GetSong(t.Context(), resp.SongID)
+			if err != nil {
+				t.Fatalf("GetSong: %v", err)
+			}
+			if got.Link != tc.wantLink || got.Text != tc.wantText {
+				t.Errorf("got link %q, text %q, want %q, %q", got.Link, got.Text, tc.wantLink, tc.wantText)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package song
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/LionJr/music-library/internal/service/validate"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AddVerse godoc
+// @Summary      	     Add verse to song
+// @Description  	     Insert a verse at position, the verses from there on move down by one. Without a position the verse is appended
+// @Tags         	     Song
+// @Accept       	     json
+// @Produce      	     json
+// @Param 			     id 	             path      integer                true   "song id"
+// @Param 			     req 	             body      models.AddVerseRequest true   "verse text and optional position, starting from 1"
+// @Success      	     200  		         {object}  models.AddVerseResponse
+// @Failure      	     400  			     {object}  models.ErrorResponse
+// @Failure      	     401  			     {object}  models.ErrorResponse
+// @Failure      	     403  			     {object}  models.ErrorResponse
+// @Failure      	     404  			     {object}  models.ErrorResponse
+// @Failure      	     500  			     {object}  models.ErrorResponse
+// @Security     	     BearerAuth
+// @Security     	     ApiKeyAuth
+// @Router       	     /songs/{id}/verses [post]
+func (s *Service) AddVerse(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	songId, err := strconv.Atoi(idParam)
+	if err != nil || songId <= 0 {
+		s.Logger.Info("song.AddVerse: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid song id", http.StatusBadRequest)
+		return
+	}
+
+	var req models.AddVerseRequest
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		s.Logger.Info("song.AddVerse: unmarshal request body", zap.Error(err))
+		respond.Error(ctx, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var validationErrors validate.Errors
+	validationErrors.Text("text", "verse text", &req.Text, true)
+	if req.Position < 0 {
+		validationErrors.Add("position", "invalid verse position")
+	}
+	if len(validationErrors) > 0 {
+		respond.ValidationErrors(ctx, validationErrors...)
+		return
+	}
+
+	exists, err := s.Repo.SongExists(ctx, songId)
+	if err != nil {
+		s.Logger.Info("song.AddVerse: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		respond.DomainError(ctx, models.ErrSongNotFound, "song does not exist", http.StatusNotFound)
+		return
+	}
+
+	index, err := s.Repo.AddVerse(ctx, songId, req.Position, req.Text)
+	if err != nil {
+		s.Logger.Info("song.AddVerse: ", zap.Error(err))
+		switch {
+		case errors.Is(err, models.ErrVerseNotFound):
+			respond.Error(ctx, "verse position is past the end of the song", http.StatusBadRequest)
+		case errors.Is(err, models.ErrSongNotFound):
+			respond.DomainError(ctx, err, "song does not exist", http.StatusNotFound)
+		default:
+			respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	resp := models.AddVerseResponse{
+		Message: "Verse successfully added",
+		Index:   index,
+	}
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
@@ -1,6 +1,8 @@
 package song
 
 import (
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"net/http"
@@ -9,41 +11,45 @@ import (
 
 // Delete godoc
 // @Summary      	     Remove song from music library
-// @Description  	     Remove song from music library by song id
+// @Description  	     Move song to the trash by song id, it can be restored until the trash is purged
 // @Tags         	     Song
 // @Accept       	     json
 // @Produce      	     json
 // @Param 			     id 	             path      integer                true   "song id"
 // @Success      	     200  		         {object}  string
 // @Failure      	     400  			     {object}  models.ErrorResponse
+// @Failure      	     401  			     {object}  models.ErrorResponse
+// @Failure      	     403  			     {object}  models.ErrorResponse
 // @Failure      	     404  			     {object}  models.ErrorResponse
 // @Failure      	     500  			     {object}  models.ErrorResponse
+// @Security     	     BearerAuth
+// @Security     	     ApiKeyAuth
 // @Router       	     /songs/{id} [delete]
 func (s *Service) Delete(ctx *gin.Context) {
 	idParam := ctx.Param("id")
 	songId, err := strconv.Atoi(idParam)
 	if err != nil || songId <= 0 {
 		s.Logger.Info("song.Delete: ", zap.String("id", idParam))
-		sendErrorResponse(ctx, "invalid song id", http.StatusBadRequest)
+		respond.Error(ctx, "invalid song id", http.StatusBadRequest)
 		return
 	}
 
 	exists, err := s.Repo.SongExists(ctx, songId)
 	if err != nil {
 		s.Logger.Info("song.Delete: ", zap.Error(err))
-		sendErrorResponse(ctx, "internal server error", http.StatusInternalServerError)
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
 		return
 	}
 
 	if !exists {
-		sendErrorResponse(ctx, "song does not exist", http.StatusNotFound)
+		respond.DomainError(ctx, models.ErrSongNotFound, "song does not exist", http.StatusNotFound)
 		return
 	}
 
 	err = s.Repo.Delete(ctx, songId)
 	if err != nil {
 		s.Logger.Info("song.Delete: ", zap.Error(err))
-		sendErrorResponse(ctx, "internal server error", http.StatusInternalServerError)
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
 		return
 	}
 
@@ -0,0 +1,75 @@
+package song
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// DeleteVerse godoc
+// @Summary      	     Remove verse from song
+// @Description  	     Remove the verse at index, the verses after it move up by one
+// @Tags         	     Song
+// @Accept       	     json
+// @Produce      	     json
+// @Param 			     id 	             path      integer                true   "song id"
+// @Param 			     index               path      integer                true   "verse index, starting from 1"
+// @Success      	     200  		         {object}  string
+// @Failure      	     400  			     {object}  models.ErrorResponse
+// @Failure      	     401  			     {object}  models.ErrorResponse
+// @Failure      	     403  			     {object}  models.ErrorResponse
+// @Failure      	     404  			     {object}  models.ErrorResponse
+// @Failure      	     500  			     {object}  models.ErrorResponse
+// @Security     	     BearerAuth
+// @Security     	     ApiKeyAuth
+// @Router       	     /songs/{id}/verses/{index} [delete]
+func (s *Service) DeleteVerse(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	songId, err := strconv.Atoi(idParam)
+	if err != nil || songId <= 0 {
+		s.Logger.Info("song.DeleteVerse: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid song id", http.StatusBadRequest)
+		return
+	}
+
+	indexParam := ctx.Param("index")
+	index, err := strconv.Atoi(indexParam)
+	if err != nil || index <= 0 {
+		s.Logger.Info("song.DeleteVerse: ", zap.String("index", indexParam))
+		respond.Error(ctx, "invalid verse index", http.StatusBadRequest)
+		return
+	}
+
+	exists, err := s.Repo.SongExists(ctx, songId)
+	if err != nil {
+		s.Logger.Info("song.DeleteVerse: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		respond.DomainError(ctx, models.ErrSongNotFound, "song does not exist", http.StatusNotFound)
+		return
+	}
+
+	err = s.Repo.DeleteVerse(ctx, songId, index)
+	if err != nil {
+		s.Logger.Info("song.DeleteVerse: ", zap.Error(err))
+		switch {
+		case errors.Is(err, models.ErrVerseNotFound):
+			respond.DomainError(ctx, err, "no verse found with provided index", http.StatusNotFound)
+		case errors.Is(err, models.ErrSongNotFound):
+			respond.DomainError(ctx, err, "song does not exist", http.StatusNotFound)
+		default:
+			respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	sendSuccessResponse(ctx, "Successfully deleted", http.StatusOK)
+}
@@ -0,0 +1,250 @@
+package song
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// DiffRevisions           godoc
+// @Summary                Compare revisions of song
+// @Description            Compare a song at two revisions: the fields that differ and the verses that were added, removed or changed from the first to the second
+// @Tags                   Song
+// @Accept                 json
+// @Produce                json
+// @Param   	           id      path      int     true          "song id"
+// @Param   	           from    query     int     true          "older revision"
+// @Param  		           to      query     int     true          "newer revision"
+// @Success      		   200    {object}  models.RevisionDiffResponse
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   404    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Router       		   /songs/{id}/revisions/diff [get]
+func (s *Service) DiffRevisions(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	songId, err := strconv.Atoi(idParam)
+	if err != nil || songId <= 0 {
+		s.Logger.Info("song.DiffRevisions: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid song id", http.StatusBadRequest)
+		return
+	}
+
+	from, err := strconv.Atoi(ctx.Query("from"))
+	if err != nil || from <= 0 {
+		respond.Error(ctx, "invalid from revision", http.StatusBadRequest)
+		return
+	}
+
+	to, err := strconv.Atoi(ctx.Query("to"))
+	if err != nil || to <= 0 {
+		respond.Error(ctx, "invalid to revision", http.StatusBadRequest)
+		return
+	}
+
+	exists, err := s.Repo.SongExists(ctx, songId)
+	if err != nil {
+		s.Logger.Info("song.DiffRevisions: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		respond.DomainError(ctx, models.ErrSongNotFound, "song does not exist", http.StatusNotFound)
+		return
+	}
+
+	revisions := make([]*models.SongRevision, 0, 2)
+	for _, revision := range []int{from, to} {
+		rev, err := s.Repo.GetRevision(ctx, songId, revision)
+		if err != nil {
+			if errors.Is(err, models.ErrRevisionNotFound) {
+				respond.DomainError(ctx, err, "revision "+strconv.Itoa(revision)+" does not exist", http.StatusNotFound)
+				return
+			}
+			s.Logger.Info("song.DiffRevisions: ", zap.Error(err))
+			respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		revisions = append(revisions, rev)
+	}
+
+	older, newer := revisions[0].State(), revisions[1].State()
+
+	resp := models.RevisionDiffResponse{
+		From:   from,
+		To:     to,
+		Fields: diffFields(older, newer),
+		Verses: diffVerses(older.Verses, newer.Verses),
+	}
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
+
+func diffFields(from, to *models.SongSnapshot) []models.FieldChange {
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{"group_name", from.GroupName, to.GroupName},
+		{"song_name", from.SongName, to.SongName},
+		{"release_date", string(from.ReleaseDate), string(to.ReleaseDate)},
+		{"link", from.Link, to.Link},
+	}
+
+	changes := []models.FieldChange{}
+	for _, field := range fields {
+		if field.from != field.to {
+			changes = append(changes, models.FieldChange{Field: field.name, From: field.from, To: field.to})
+		}
+	}
+
+	return changes
+}
+
+// maxLCSTable bounds the cells of the table of longest common subsequences
+// that diffVerses fills at once. Longer revisions are first split in halves by
+// Hirschberg's algorithm, which keeps only two rows of the table.
+const maxLCSTable = 1 << 16
+
+// diffVerses matches the verses of two revisions by their longest common
+// subsequence. Between two matched verses, removed and added verses are
+// paired up in order and reported as changed.
+func diffVerses(from, to []string) []models.VerseChange {
+	changes := []models.VerseChange{}
+	var removed, added []int
+
+	flush := func() {
+		paired := min(len(removed), len(added))
+		for k := 0; k < paired; k++ {
+			changes = append(changes, models.VerseChange{
+				Change:    models.VerseChanged,
+				FromIndex: removed[k] + 1,
+				ToIndex:   added[k] + 1,
+				From:      from[removed[k]],
+				To:        to[added[k]],
+			})
+		}
+		for _, i := range removed[paired:] {
+			changes = append(changes, models.VerseChange{Change: models.VerseRemoved, FromIndex: i + 1, From: from[i]})
+		}
+		for _, j := range added[paired:] {
+			changes = append(changes, models.VerseChange{Change: models.VerseAdded, ToIndex: j + 1, To: to[j]})
+		}
+		removed, added = removed[:0], added[:0]
+	}
+
+	i, j := 0, 0
+	for _, match := range commonVerses(from, to, 0, 0, nil) {
+		for ; i < match[0]; i++ {
+			removed = append(removed, i)
+		}
+		for ; j < match[1]; j++ {
+			added = append(added, j)
+		}
+		flush()
+		i++
+		j++
+	}
+	for ; i < len(from); i++ {
+		removed = append(removed, i)
+	}
+	for ; j < len(to); j++ {
+		added = append(added, j)
+	}
+	flush()
+
+	return changes
+}
+
+// commonVerses appends to matches the indexes, shifted by fromOffset and
+// toOffset, of the verses in a longest common subsequence of from and to.
+func commonVerses(from, to []string, fromOffset, toOffset int, matches [][2]int) [][2]int {
+	if len(from) == 0 || len(to) == 0 {
+		return matches
+	}
+	if len(from) == 1 || (len(from)+1)*(len(to)+1) <= maxLCSTable {
+		return tableVerses(from, to, fromOffset, toOffset, matches)
+	}
+
+	// Split to where the halves of from have the longest common
+	// subsequences with its two parts together.
+	mid := len(from) / 2
+	upper := lcsLengths(from[:mid], to)
+	lower := lcsLengths(reversed(from[mid:]), reversed(to))
+	split := 0
+	for j := range upper {
+		if upper[j]+lower[len(to)-j] > upper[split]+lower[len(to)-split] {
+			split = j
+		}
+	}
+
+	matches = commonVerses(from[:mid], to[:split], fromOffset, toOffset, matches)
+	return commonVerses(from[mid:], to[split:], fromOffset+mid, toOffset+split, matches)
+}
+
+// tableVerses is commonVerses by the full table of longest common
+// subsequences.
+func tableVerses(from, to []string, fromOffset, toOffset int, matches [][2]int) [][2]int {
+	// lcs[i][j] is the length of the longest common subsequence of from[i:]
+	// and to[j:].
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(from) && j < len(to) {
+		switch {
+		case from[i] == to[j]:
+			matches = append(matches, [2]int{fromOffset + i, toOffset + j})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	return matches
+}
+
+// lcsLengths returns the lengths of the longest common subsequences of from
+// and every prefix to[:j] of to, keeping two rows of the table.
+func lcsLengths(from, to []string) []int {
+	prev := make([]int, len(to)+1)
+	cur := make([]int, len(to)+1)
+	for _, verse := range from {
+		for j := range to {
+			if verse == to[j] {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(prev[j+1], cur[j])
+			}
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev
+}
+
+func reversed(verses []string) []string {
+	verses = slices.Clone(verses)
+	slices.Reverse(verses)
+	return verses
+}
@@ -0,0 +1,82 @@
+package song_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+func TestDiffRevisions(t *testing.T) {
+	// Many verses, beyond the table diffVerses fills at once.
+	var many []string
+	for i := 1; i <= 1000; i++ {
+		many = append(many, fmt.Sprintf("verse %d", i))
+	}
+	edited := append([]string{}, many[:99]...)
+	edited = append(edited, many[100:499]...)
+	edited = append(edited, "verse 500, sung twice")
+	edited = append(edited, many[500:]...)
+	edited = append(edited, "coda")
+
+	for _, tc := range []struct {
+		name     string
+		from, to []string
+		want     []models.VerseChange
+	}{
+		{
+			name: "few verses",
+			from: []string{"a", "b", "c", "d"},
+			to:   []string{"a", "x", "c", "d", "e"},
+			want: []models.VerseChange{
+				{Change: models.VerseChanged, FromIndex: 2, ToIndex: 2, From: "b", To: "x"},
+				{Change: models.VerseAdded, ToIndex: 5, To: "e"},
+			},
+		},
+		{
+			name: "many verses",
+			from: many,
+			to:   edited,
+			want: []models.VerseChange{
+				{Change: models.VerseRemoved, FromIndex: 100, From: "verse 100"},
+				{Change: models.VerseChanged, FromIndex: 500, ToIndex: 499, From: "verse 500", To: "verse 500, sung twice"},
+				{Change: models.VerseAdded, ToIndex: 1000, To: "coda"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newTestService(&fakeMetadata{})
+			id, err := s.Repo.Add(t.Context(), &models.Song{GroupName: "Muse", SongName: "Uprising", Text: strings.Join(tc.from, "\n\n")})
+			if err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+			if _, err = s.Repo.ReplaceText(t.Context(), id, strings.Join(tc.to, "\n\n")); err != nil {
+				t.Fatalf("ReplaceText: %v", err)
+			}
+
+			diff := func(ctx *gin.Context) {
+				ctx.Params = gin.Params{{Key: "id", Value: strconv.Itoa(id)}}
+				s.DiffRevisions(ctx)
+			}
+			w := serve(diff, http.MethodGet, "/songs/1/revisions/diff?from=1&to=2", "", nil)
+			if w.Code != http.StatusOK {
+				t.Fatalf("got status %d, want 200: %s", w.Code, w.Body)
+			}
+
+			var resp models.RevisionDiffResponse
+			if err = json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode body %q: %v", w.Body, err)
+			}
+			if !reflect.DeepEqual(resp.Verses, tc.want) {
+				t.Errorf("got verses %+v, want %+v", resp.Verses, tc.want)
+			}
+		})
+	}
+}
@@ -1,108 +1,136 @@
 package song
 
 import (
+	"errors"
 	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/LionJr/music-library/internal/service/validate"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"net/http"
-	"net/url"
 	"strconv"
 	"strings"
-	"time"
 )
 
 const layout = "02.01.2006"
 
 // Edit godoc
 // @Summary     Update song
-// @Description Update song properties by song id
+// @Description Update song properties by song id. With If-Match set to the ETag of the song the update only applies if the song was not changed since
 // @Tags        Song
 // @Accept      json
 // @Produce     json
 // @Param       req  body     models.EditSongRequest true "Song field(s) need to be updated"
 // @Param       id   path     integer                true "Song id"
+// @Param       If-Match header string               false "ETags the song must have for the update to apply, weak ones never match"
 // @Success     200  {object} models.SuccessResponse "Song successfully updated"
+// @Header      200  {string} ETag "new ETag of the song"
 // @Failure     400  {object} models.ErrorResponse
+// @Failure     401  {object} models.ErrorResponse
+// @Failure     403  {object} models.ErrorResponse
 // @Failure     404  {object} models.ErrorResponse
 // @Failure     409  {object} models.ErrorResponse
+// @Failure     412  {object} models.ErrorResponse
 // @Failure     500  {object} models.ErrorResponse
+// @Security    BearerAuth
+// @Security    ApiKeyAuth
 // @Router      /songs/{id} [patch]
 func (s *Service) Edit(ctx *gin.Context) {
 	idParam := ctx.Param("id")
 	songId, err := strconv.Atoi(idParam)
 	if err != nil || songId <= 0 {
 		s.Logger.Info("song.Edit: ", zap.String("id", idParam))
-		sendErrorResponse(ctx, "invalid song id", http.StatusBadRequest)
+		respond.Error(ctx, "invalid song id", http.StatusBadRequest)
 		return
 	}
 
 	var req models.EditSongRequest
 	if err = ctx.ShouldBindJSON(&req); err != nil {
 		s.Logger.Info("song.Edit: unmarshal request body", zap.Error(err))
-		sendErrorResponse(ctx, "invalid request body", http.StatusBadRequest)
+		respond.Error(ctx, "invalid request body", http.StatusBadRequest)
 		return
 	}
 
-	exists, err := s.Repo.SongExists(ctx, songId)
+	song, err := s.Repo.GetSong(ctx, songId)
 	if err != nil {
+		if errors.Is(err, models.ErrSongNotFound) {
+			respond.DomainError(ctx, err, "song does not exist", http.StatusNotFound)
+			return
+		}
+
 		s.Logger.Info("song.Edit: ", zap.Error(err))
-		sendErrorResponse(ctx, "internal server error", http.StatusInternalServerError)
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
 		return
 	}
 
-	if !exists {
-		sendErrorResponse(ctx, "song does not exist", http.StatusNotFound)
-		return
+	// "*" only asks for the song to exist. A list of tags has to hold the
+	// ETag of the song by the strong comparison, and the edit then applies
+	// only to the version that matched.
+	version := 0
+	if ifMatch := strings.Join(ctx.Request.Header.Values("If-Match"), ","); ifMatch != "" && strings.TrimSpace(ifMatch) != "*" {
+		if !etagListMatches(ifMatch, songETag(song.Version), true) {
+			respond.DomainError(ctx, models.ErrVersionMismatch, "song was changed in the meantime", http.StatusPreconditionFailed)
+			return
+		}
+		version = song.Version
 	}
 
 	validationResult := validateInput(&req)
 	if len(validationResult) > 0 {
-		message := strings.Join(validationResult, "; ")
-		sendErrorResponse(ctx, message, http.StatusBadRequest)
+		respond.ValidationErrors(ctx, validationResult...)
 		return
 	}
 
-	err = s.Repo.Edit(ctx, songId, &req)
+	version, err = s.Repo.Edit(ctx, songId, &req, version)
 	if err != nil {
-		s.Logger.Error("song.Edit", zap.Error(err))
-		sendErrorResponse(ctx, "internal server error", http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, models.ErrVersionMismatch):
+			respond.DomainError(ctx, err, "song was changed in the meantime", http.StatusPreconditionFailed)
+		case errors.Is(err, models.ErrNotFound):
+			respond.DomainError(ctx, err, err.Error(), http.StatusNotFound)
+		case errors.Is(err, models.ErrDuplicate):
+			respond.DomainError(ctx, err, "group already has a song with this name", http.StatusConflict)
+		default:
+			s.Logger.Error("song.Edit", zap.Error(err))
+			respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		}
 		return
 	}
 
-	sendSuccessResponse(ctx, "OK", http.StatusOK)
+	ctx.Header("ETag", songETag(version))
+	sendSuccessResponse(ctx, models.SuccessResponse{Message: "Song successfully updated"}, http.StatusOK)
 }
 
-func validateInput(input *models.EditSongRequest) []string {
-	validationErrors := make([]string, 0)
+func validateInput(input *models.EditSongRequest) []models.FieldError {
+	var validationErrors validate.Errors
 
 	if input.GroupName != nil {
-		*input.GroupName = sanitizeForSQL(*input.GroupName)
+		validationErrors.Name("group", "group name", input.GroupName, maxGroupNameLength)
 	}
 
 	if input.SongName != nil {
-		*input.SongName = sanitizeForSQL(*input.SongName)
+		validationErrors.Name("song", "song name", input.SongName, maxSongNameLength)
 	}
 
 	if input.ReleaseDate != nil {
-		_, err := time.Parse(layout, *input.ReleaseDate)
+		releaseDate, err := parseReleaseDate(strings.TrimSpace(*input.ReleaseDate))
 		if err != nil {
-			validationErrors = append(validationErrors, "invalid release date")
+			validationErrors.Add("release_date", "invalid release date")
+		} else {
+			*input.ReleaseDate = releaseDate.Format(models.DateLayout)
 		}
 	}
 
 	if input.Link != nil {
-		parsedURL, err := url.ParseRequestURI(*input.Link)
-		if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
-			validationErrors = append(validationErrors, "invalid link")
-		}
+		validationErrors.Link("link", "link", *input.Link, maxLinkLength)
 	}
 
 	if input.Verse != nil {
 		if input.Verse.Index <= 0 {
-			validationErrors = append(validationErrors, "invalid verse index")
+			validationErrors.Add("verse.index", "invalid verse index")
 		}
 
-		input.Verse.Text = sanitizeForSQL(input.Verse.Text)
+		validationErrors.Text("verse.text", "verse text", &input.Verse.Text, true)
 	}
 
 	return validationErrors
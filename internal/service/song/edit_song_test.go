@@ -0,0 +1,94 @@
+package song_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+func TestEdit(t *testing.T) {
+	s := newTestService(&fakeMetadata{})
+	id, err := s.Repo.Add(t.Context(), &models.Song{GroupName: "Muse", SongName: "Uprising"})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err = s.Repo.Add(t.Context(), &models.Song{GroupName: "Muse", SongName: "Hysteria"}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	edit := func(id int) gin.HandlerFunc {
+		return func(ctx *gin.Context) {
+			ctx.Params = gin.Params{{Key: "id", Value: strconv.Itoa(id)}}
+			s.Edit(ctx)
+		}
+	}
+
+	for _, tc := range []struct {
+		name    string
+		id      int
+		body    string
+		ifMatch string
+		status  int
+		code    string
+	}{
+		{"missing song", id + 100, `{"song": "Madness"}`, "", http.StatusNotFound, models.CodeSongNotFound},
+		{"duplicate name", id, `{"song": "Hysteria"}`, "", http.StatusConflict, models.CodeSongExists},
+		{"stale version", id, `{"song": "Madness"}`, `"7"`, http.StatusPreconditionFailed, models.CodeVersionMismatch},
+		{"malformed ETag", id, `{"song": "Madness"}`, `7`, http.StatusPreconditionFailed, models.CodeVersionMismatch},
+		{"weak ETag", id, `{"song": "Madness"}`, `W/"1"`, http.StatusPreconditionFailed, models.CodeVersionMismatch},
+		{"weak ETag in a list", id, `{"song": "Madness"}`, `"7", W/"1"`, http.StatusPreconditionFailed, models.CodeVersionMismatch},
+		{"text ETag", id, `{"song": "Madness"}`, `"1-text"`, http.StatusPreconditionFailed, models.CodeVersionMismatch},
+		{"invalid field", id, `{"song": ""}`, "", http.StatusBadRequest, models.CodeValidationFailed},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			if tc.ifMatch != "" {
+				header.Set("If-Match", tc.ifMatch)
+			}
+
+			w := serve(edit(tc.id), http.MethodPatch, "/songs/1", tc.body, header)
+			if w.Code != tc.status {
+				t.Fatalf("got status %d, want %d", w.Code, tc.status)
+			}
+
+			var resp models.ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode body %q: %v", w.Body, err)
+			}
+			if resp.Code != tc.code {
+				t.Errorf("got code %q, want %q", resp.Code, tc.code)
+			}
+		})
+	}
+
+	w := serve(edit(id), http.MethodPatch, "/songs/1", `{"song": "Madness"}`, http.Header{"If-Match": {`"7", "1"`}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("got status %d, want 200: %s", w.Code, w.Body)
+	}
+
+	var resp models.SuccessResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode body %q: %v", w.Body, err)
+	}
+	if resp.Message != "Song successfully updated" {
+		t.Errorf("got message %q, want %q", resp.Message, "Song successfully updated")
+	}
+	if strings.Contains(w.Body.String(), `"data"`) {
+		t.Errorf("got body %s, want no data", w.Body)
+	}
+	if etag := w.Header().Get("ETag"); etag != `"2"` {
+		t.Errorf("got ETag %q, want \"2\"", etag)
+	}
+
+	for _, ifMatch := range []string{`*`, `W/"3", "3"`} {
+		if w = serve(edit(id), http.MethodPatch, "/songs/1", `{"song": "Madness"}`, http.Header{"If-Match": {ifMatch}}); w.Code != http.StatusOK {
+			t.Errorf("If-Match %s: got status %d, want 200: %s", ifMatch, w.Code, w.Body)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package song
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	exportFormatCSV    = "csv"
+	exportFormatJSON   = "json"
+	exportFormatNDJSON = "ndjson"
+
+	// exportFlushEvery songs are buffered before the response is flushed.
+	exportFlushEvery = 100
+)
+
+// exportCSVHeader matches the columns the import reads, so an export can be
+// imported elsewhere as is.
+var exportCSVHeader = []string{"id", "group", "song", "release_date", "link", "text", "created_at", "updated_at", "track_number"}
+
+// Export                  godoc
+// @Summary                Export songs
+// @Description            Stream every song matching the filters of GET /songs together with its verses, in the order GET /songs would list them. Songs are read in batches, so exports of any size use the same memory. JSON and NDJSON songs carry their verses and the joined text, CSV rows the text only
+// @Tags                   Song
+// @Produce                json,text/csv,application/x-ndjson
+// @Param   	           format           query     string  false       "export format, ndjson by default" Enums(ndjson, csv, json)
+// @Param   	           album            query     int     false       "album id"
+// @Param   	           group            query     string  false       "case-insensitive substring of the group name"
+// @Param  		           song             query     string  false       "case-insensitive substring of the song name"
+// @Param  		           released_after   query     string  false       "earliest release date, inclusive (2006-01-02 or 02.01.2006)"
+// @Param  		           released_before  query     string  false       "latest release date, inclusive (2006-01-02 or 02.01.2006)"
+// @Param  		           created_after    query     string  false       "earliest creation time, inclusive (RFC 3339 or 2006-01-02)"
+// @Param  		           created_before   query     string  false       "latest creation time, inclusive (RFC 3339 or 2006-01-02)"
+// @Param  		           sort             query     string  false       "column to sort by" Enums(id, group_name, song_name, release_date, link, created_at, updated_at, track_number)
+// @Param  		           order            query     string  false       "sort direction" Enums(asc, desc)
+// @Success      		   200    {array}   models.SongExport
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Router       		   /songs/export [get]
+func (s *Service) Export(ctx *gin.Context) {
+	format := strings.ToLower(ctx.DefaultQuery("format", exportFormatNDJSON))
+
+	filter, validationResult := parseSongFilter(ctx)
+	switch format {
+	case exportFormatCSV, exportFormatJSON, exportFormatNDJSON:
+	default:
+		validationResult = append(validationResult, models.FieldError{Field: "format", Message: "invalid export format"})
+	}
+	if len(validationResult) > 0 {
+		respond.ValidationErrors(ctx, validationResult...)
+		return
+	}
+
+	w := newExportWriter(ctx, format)
+
+	exported := 0
+	err := s.Repo.ExportSongs(ctx, filter, func(song *models.SongExport) error {
+		if err := w.write(song); err != nil {
+			return err
+		}
+
+		exported++
+		if exported%exportFlushEvery == 0 {
+			return w.flush()
+		}
+		return nil
+	})
+	if err == nil {
+		err = w.close()
+	}
+	if err != nil {
+		s.Logger.Info("song.Export: ", zap.Error(err), zap.Int("exported", exported))
+		if ctx.Writer.Written() {
+			abortStream(ctx)
+		} else {
+			respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	s.Logger.Info("song.Export: export finished", zap.String("format", format), zap.Int("exported", exported))
+}
+
+// abortStream drops the connection of a response that is already under way.
+// Past the headers the status cannot change, and a truncated export must not
+// look complete.
+func abortStream(ctx *gin.Context) {
+	w := http.ResponseWriter(ctx.Writer)
+	if unwrapper, ok := w.(interface{ Unwrap() http.ResponseWriter }); ok {
+		w = unwrapper.Unwrap()
+	}
+
+	if conn, _, err := http.NewResponseController(w).Hijack(); err == nil {
+		_ = conn.Close()
+	}
+	ctx.Abort()
+}
+
+// exportWriter writes songs in one of the export formats. Nothing is sent
+// before the first song or close, so errors before that can still be
+// reported with a status code.
+type exportWriter struct {
+	ctx     *gin.Context
+	format  string
+	started bool
+	csv     *csv.Writer
+	json    *json.Encoder
+}
+
+func newExportWriter(ctx *gin.Context, format string) *exportWriter {
+	return &exportWriter{ctx: ctx, format: format}
+}
+
+func (w *exportWriter) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+
+	header := w.ctx.Writer.Header()
+	header.Set("Content-Disposition", `attachment; filename="songs.`+w.format+`"`)
+
+	switch w.format {
+	case exportFormatCSV:
+		header.Set("Content-Type", "text/csv; charset=utf-8")
+		w.ctx.Status(http.StatusOK)
+		w.csv = csv.NewWriter(w.ctx.Writer)
+		return w.csv.Write(exportCSVHeader)
+	case exportFormatJSON:
+		header.Set("Content-Type", "application/json; charset=utf-8")
+		w.ctx.Status(http.StatusOK)
+		w.json = json.NewEncoder(w.ctx.Writer)
+		_, err := w.ctx.Writer.WriteString("[")
+		return err
+	default:
+		header.Set("Content-Type", "application/x-ndjson")
+		w.ctx.Status(http.StatusOK)
+		w.json = json.NewEncoder(w.ctx.Writer)
+		return nil
+	}
+}
+
+func (w *exportWriter) write(song *models.SongExport) error {
+	first := !w.started
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	switch w.format {
+	case exportFormatCSV:
+		trackNumber := ""
+		if song.TrackNumber != nil {
+			trackNumber = strconv.Itoa(*song.TrackNumber)
+		}
+
+		return w.csv.Write([]string{
+			strconv.Itoa(song.ID), song.GroupName, song.SongName, string(song.ReleaseDate), song.Link,
+			song.Text, song.CreatedAt, song.UpdatedAt, trackNumber,
+		})
+	case exportFormatJSON:
+		if !first {
+			if _, err := w.ctx.Writer.WriteString(","); err != nil {
+				return err
+			}
+		}
+		return w.json.Encode(song)
+	default:
+		return w.json.Encode(song)
+	}
+}
+
+func (w *exportWriter) flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+
+	w.ctx.Writer.Flush()
+	return nil
+}
+
+func (w *exportWriter) close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	if w.format == exportFormatJSON {
+		if _, err := w.ctx.Writer.WriteString("]"); err != nil {
+			return err
+		}
+	}
+
+	return w.flush()
+}
@@ -0,0 +1,77 @@
+package song
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GetRevisions            godoc
+// @Summary                Get revisions of song
+// @Description            Get the change history of a song, newest first, with pagination, default pagination value will be 3. Each revision holds the song before and after the change
+// @Tags                   Song
+// @Accept                 json
+// @Produce                json
+// @Param   	           id      path      int     true          "song id"
+// @Param   	           page    query     int     false         "page number in pagination"
+// @Param  		           limit   query     int     false         "number of elements in one page, at most 100"
+// @Success      		   200    {object}  models.GetSongRevisionsResponse
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   404    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Router       		   /songs/{id}/revisions [get]
+func (s *Service) GetRevisions(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	songId, err := strconv.Atoi(idParam)
+	if err != nil || songId <= 0 {
+		s.Logger.Info("song.GetRevisions: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid song id", http.StatusBadRequest)
+		return
+	}
+
+	exists, err := s.Repo.SongExists(ctx, songId)
+	if err != nil {
+		s.Logger.Info("song.GetRevisions: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		respond.DomainError(ctx, models.ErrSongNotFound, "song does not exist", http.StatusNotFound)
+		return
+	}
+
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = models.DefaultPaginationPage
+	}
+
+	limit, err := strconv.Atoi(ctx.Query("limit"))
+	if err != nil || limit < 1 {
+		limit = models.DefaultPaginationSize
+	}
+	limit = min(limit, models.MaxPaginationSize)
+
+	revisions, totalRevisionCount, err := s.Repo.GetRevisions(ctx, songId, page, limit)
+	if err != nil {
+		s.Logger.Info("song.GetRevisions: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if revisions == nil {
+		revisions = []models.SongRevision{}
+	}
+
+	resp := models.GetSongRevisionsResponse{
+		Revisions:          revisions,
+		TotalRevisionCount: totalRevisionCount,
+		Page:               page,
+	}
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
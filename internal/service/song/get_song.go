@@ -0,0 +1,74 @@
+package song
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"go.uber.org/zap"
+)
+
+// GetSong                 godoc
+// @Summary                Get song
+// @Description            Get every field of a song together with its full text, joined from its verses. With Accept: text/plain only the text is returned. The ETag changes with every change of the song or its verses
+// @Tags                   Song
+// @Accept                 json
+// @Produce                json
+// @Produce                plain
+// @Param   	           id      path      int     true          "song id"
+// @Param   	           If-None-Match  header  string  false  "ETags of copies of the song the client already has, or *"
+// @Success      		   200    {object}  models.Song
+// @Header      		   200    {string}  ETag  "version of the song, with a -text suffix for the text"
+// @Header      		   200    {string}  Vary  "Accept"
+// @Success      		   304
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   404    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Router       		   /songs/{id} [get]
+func (s *Service) GetSong(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	songId, err := strconv.Atoi(idParam)
+	if err != nil || songId <= 0 {
+		s.Logger.Info("song.GetSong: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid song id", http.StatusBadRequest)
+		return
+	}
+
+	song, err := s.Repo.GetSong(ctx, songId)
+	if err != nil {
+		if errors.Is(err, models.ErrSongNotFound) {
+			respond.DomainError(ctx, err, "song does not exist", http.StatusNotFound)
+			return
+		}
+
+		s.Logger.Info("song.GetSong: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// The JSON and the text of a song are told apart by their ETags, and
+	// caches by Accept.
+	plain := ctx.NegotiateFormat(binding.MIMEJSON, binding.MIMEPlain) == binding.MIMEPlain
+	etag := songETag(song.Version)
+	if plain {
+		etag = songTextETag(song.Version)
+	}
+	ctx.Header("Vary", "Accept")
+	ctx.Header("ETag", etag)
+	if ifNoneMatch := ctx.Request.Header.Values("If-None-Match"); len(ifNoneMatch) > 0 && etagListMatches(strings.Join(ifNoneMatch, ","), etag, false) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	if plain {
+		ctx.String(http.StatusOK, song.Text)
+		return
+	}
+
+	sendSuccessResponse(ctx, song, http.StatusOK)
+}
@@ -0,0 +1,92 @@
+package song_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+func TestGetSongIfNoneMatch(t *testing.T) {
+	s := newTestService(&fakeMetadata{})
+	id, err := s.Repo.Add(t.Context(), &models.Song{GroupName: "Muse", SongName: "Uprising"})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	getSong := func(ctx *gin.Context) {
+		ctx.Params = gin.Params{{Key: "id", Value: strconv.Itoa(id)}}
+		s.GetSong(ctx)
+	}
+
+	w := serve(getSong, http.MethodGet, "/songs/1", "", nil)
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || etag != `"1"` {
+		t.Fatalf("got status %d, ETag %q, want 200, \"1\"", w.Code, etag)
+	}
+
+	for _, tc := range []struct {
+		name        string
+		ifNoneMatch []string
+		status      int
+	}{
+		{"same", []string{`"1"`}, http.StatusNotModified},
+		{"weak", []string{`W/"1"`}, http.StatusNotModified},
+		{"list", []string{`"0", "1"`}, http.StatusNotModified},
+		{"list without spaces", []string{`W/"0",W/"1"`}, http.StatusNotModified},
+		{"several headers", []string{`"0"`, `"1"`}, http.StatusNotModified},
+		{"any", []string{`*`}, http.StatusNotModified},
+		{"comma in a tag", []string{`"1,2"`}, http.StatusOK},
+		{"other", []string{`"2"`}, http.StatusOK},
+		{"unquoted", []string{`1`}, http.StatusOK},
+		{"unterminated", []string{`"1`}, http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serve(getSong, http.MethodGet, "/songs/1", "", http.Header{"If-None-Match": tc.ifNoneMatch})
+			if w.Code != tc.status {
+				t.Errorf("got status %d, want %d", w.Code, tc.status)
+			}
+		})
+	}
+}
+
+func TestGetSongTextETag(t *testing.T) {
+	s := newTestService(&fakeMetadata{})
+	id, err := s.Repo.Add(t.Context(), &models.Song{GroupName: "Muse", SongName: "Uprising"})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	getSong := func(ctx *gin.Context) {
+		ctx.Params = gin.Params{{Key: "id", Value: strconv.Itoa(id)}}
+		s.GetSong(ctx)
+	}
+
+	plain := http.Header{"Accept": {"text/plain"}}
+	w := serve(getSong, http.MethodGet, "/songs/1", "", plain)
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || etag != `"1-text"` {
+		t.Fatalf("got status %d, ETag %q, want 200, \"1-text\"", w.Code, etag)
+	}
+	if vary := w.Header().Get("Vary"); vary != "Accept" {
+		t.Errorf("got Vary %q, want Accept", vary)
+	}
+
+	plain.Set("If-None-Match", `"1"`)
+	if w = serve(getSong, http.MethodGet, "/songs/1", "", plain); w.Code != http.StatusOK {
+		t.Errorf("ETag of the JSON: got status %d, want 200", w.Code)
+	}
+
+	plain.Set("If-None-Match", etag)
+	if w = serve(getSong, http.MethodGet, "/songs/1", "", plain); w.Code != http.StatusNotModified {
+		t.Errorf("ETag of the text: got status %d, want 304", w.Code)
+	}
+
+	w = serve(getSong, http.MethodGet, "/songs/1", "", http.Header{"If-None-Match": {etag}})
+	if w.Code != http.StatusOK || w.Header().Get("Vary") != "Accept" {
+		t.Errorf("JSON with the ETag of the text: got status %d, Vary %q, want 200, Accept", w.Code, w.Header().Get("Vary"))
+	}
+}
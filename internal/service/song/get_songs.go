@@ -1,58 +1,165 @@
 package song
 
 import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/pagination"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/LionJr/music-library/internal/service/validate"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
-	"net/http"
-	"strconv"
 )
 
+const songsCursorScope = "songs"
+
 // GetSongs                godoc
 // @Summary                Get songs
-// @Description            Get songs by group and song with pagination, default pagination value will be 3
+// @Description            Get songs filtered by album, group, song, release and creation dates with sorting and pagination, default pagination value will be 3. Songs of an album carry their track number and are sorted by it unless sort is set
 // @Tags                   Song
 // @Accept                 json
 // @Produce                json
-// @Param   	           group   query     string  false       "page number in pagination"
-// @Param  		           song    query     string  false       "number of elements in one page"
-// @Param   	           page    query     int     false       "page number in pagination"
-// @Param  		           limit   query     int     false       "number of elements in one page"
+// @Param   	           album            query     int     false       "album id"
+// @Param   	           group            query     string  false       "case-insensitive substring of the group name"
+// @Param  		           song             query     string  false       "case-insensitive substring of the song name"
+// @Param  		           released_after   query     string  false       "earliest release date, inclusive (2006-01-02 or 02.01.2006)"
+// @Param  		           released_before  query     string  false       "latest release date, inclusive (2006-01-02 or 02.01.2006)"
+// @Param  		           created_after    query     string  false       "earliest creation time, inclusive (RFC 3339 or 2006-01-02)"
+// @Param  		           created_before   query     string  false       "latest creation time, inclusive (RFC 3339 or 2006-01-02)"
+// @Param  		           sort             query     string  false       "column to sort by" Enums(id, group_name, song_name, release_date, link, created_at, updated_at, track_number)
+// @Param  		           order            query     string  false       "sort direction" Enums(asc, desc)
+// @Param   	           page             query     int     false       "page number in pagination, ignored when cursor is set"
+// @Param   	           cursor           query     string  false       "next_cursor or prev_cursor from a previous response with the same filters and sorting"
+// @Param  		           limit            query     int     false       "number of elements in one page, at most 100"
 // @Success      		   200    {object}  models.GetSongsResponse
 // @Failure      		   400    {object}  models.ErrorResponse
-// @Failure      		   400    {object}  models.ErrorResponse
-// @Failure      		   409    {object}  models.ErrorResponse
 // @Failure      		   500    {object}  models.ErrorResponse
 // @Router       		   /songs [get]
 func (s *Service) GetSongs(ctx *gin.Context) {
-	group := ctx.Param("group")
-	song := ctx.Param("song")
-
-	group = sanitizeForSQL(group)
-	song = sanitizeForSQL(song)
-
-	page, err := strconv.Atoi(ctx.Query("page"))
-	if err != nil || page < 1 {
-		page = models.DefaultPaginationPage
+	filter, validationResult := parseSongFilter(ctx)
+	if len(validationResult) > 0 {
+		respond.ValidationErrors(ctx, validationResult...)
+		return
 	}
 
-	limit, err := strconv.Atoi(ctx.Query("limit"))
-	if err != nil || limit < 1 {
-		limit = models.DefaultPaginationSize
+	req, err := s.cursors.ParsePage(ctx, songsCursorScope, filter.SortBy, filter.SortDesc, filter)
+	if err != nil {
+		respond.Error(ctx, "invalid cursor", http.StatusBadRequest)
+		return
 	}
 
-	songs, totalSongCount, err := s.Repo.GetSongs(ctx, group, song, page, limit)
+	songs, totalSongCount, err := s.Repo.GetSongs(ctx, filter, req.Page)
 	if err != nil {
 		s.Logger.Info("song.GetSongs", zap.Error(err))
-		sendErrorResponse(ctx, "internal server error", http.StatusInternalServerError)
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
 		return
 	}
 
+	songs, next, prev := pagination.Paginate(s.cursors, req, songs, songsCursorScope, filter.SortBy, filter.SortDesc,
+		func(song *models.Song) (string, int) { return song.SortValue(filter.SortBy), song.ID },
+	)
+
 	resp := models.GetSongsResponse{
 		Songs:          songs,
 		TotalSongCount: totalSongCount,
-		Page:           page,
+		Page:           req.Number,
+		NextCursor:     next,
+		PrevCursor:     prev,
 	}
 
 	sendSuccessResponse(ctx, resp, http.StatusOK)
 }
+
+func parseSongFilter(ctx *gin.Context) (*models.SongFilter, []models.FieldError) {
+	validationErrors := make([]models.FieldError, 0)
+
+	filter := &models.SongFilter{
+		Group:  validate.Query(ctx.Query("group")),
+		Song:   validate.Query(ctx.Query("song")),
+		SortBy: ctx.Query("sort"),
+	}
+
+	if albumParam := ctx.Query("album"); albumParam != "" {
+		albumId, err := strconv.Atoi(albumParam)
+		if err != nil || albumId <= 0 {
+			validationErrors = append(validationErrors, models.FieldError{Field: "album", Message: "invalid album id"})
+		} else {
+			filter.AlbumID = albumId
+		}
+	}
+
+	switch {
+	case filter.SortBy == "" && filter.AlbumID != 0:
+		filter.SortBy = "track_number"
+	case filter.SortBy == "track_number" && filter.AlbumID == 0:
+		validationErrors = append(validationErrors, models.FieldError{Field: "sort", Message: "sorting by track number requires an album"})
+	case filter.SortBy != "" && !models.SongSortColumns[filter.SortBy]:
+		validationErrors = append(validationErrors, models.FieldError{Field: "sort", Message: "invalid sort column"})
+	}
+
+	switch ctx.Query("order") {
+	case "", models.SortOrderAsc:
+	case models.SortOrderDesc:
+		filter.SortDesc = true
+	default:
+		validationErrors = append(validationErrors, models.FieldError{Field: "order", Message: "invalid sort order"})
+	}
+
+	dates := []struct {
+		param string
+		dest  **time.Time
+		parse func(string) (time.Time, error)
+	}{
+		{"released_after", &filter.ReleasedAfter, parseReleaseDate},
+		{"released_before", &filter.ReleasedBefore, parseReleaseDate},
+		{"created_after", &filter.CreatedAfter, parseTimestamp},
+		{"created_before", &filter.CreatedBefore, parseTimestampEnd},
+	}
+
+	for _, d := range dates {
+		value := ctx.Query(d.param)
+		if value == "" {
+			continue
+		}
+
+		t, err := d.parse(value)
+		if err != nil {
+			validationErrors = append(validationErrors, models.FieldError{Field: d.param, Message: "invalid " + strings.ReplaceAll(d.param, "_", " ")})
+			continue
+		}
+		*d.dest = &t
+	}
+
+	return filter, validationErrors
+}
+
+func parseReleaseDate(value string) (time.Time, error) {
+	if t, err := time.Parse(models.DateLayout, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(layout, value)
+}
+
+func parseTimestamp(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(models.DateLayout, value)
+}
+
+// parseTimestampEnd is parseTimestamp for upper bounds: a bare date covers
+// the whole day.
+func parseTimestampEnd(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(models.DateLayout, value)
+	if err != nil {
+		return t, err
+	}
+	return t.Add(24*time.Hour - time.Nanosecond), nil
+}
@@ -0,0 +1,44 @@
+package song_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+func TestGetSongsCursorIsBoundToFilter(t *testing.T) {
+	s := newTestService(&fakeMetadata{})
+	for _, name := range []string{"Hysteria", "Starlight", "Uprising"} {
+		if _, err := s.Repo.Add(t.Context(), &models.Song{GroupName: "Muse", SongName: name}); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	w := serve(s.GetSongs, http.MethodGet, "/songs?group=muse&limit=1", "", nil)
+	var resp models.GetSongsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.NextCursor == "" {
+		t.Fatalf("first page: got %s, want a next cursor", w.Body)
+	}
+
+	for _, tc := range []struct {
+		query  url.Values
+		status int
+	}{
+		{url.Values{"group": {"muse"}}, http.StatusOK},
+		{url.Values{"group": {" muse "}}, http.StatusOK},
+		{url.Values{"group": {"queen"}}, http.StatusBadRequest},
+		{url.Values{}, http.StatusBadRequest},
+		{url.Values{"group": {"muse"}, "released_after": {"2006-01-02"}}, http.StatusBadRequest},
+	} {
+		tc.query.Set("limit", "1")
+		tc.query.Set("cursor", resp.NextCursor)
+
+		w := serve(s.GetSongs, http.MethodGet, "/songs?"+tc.query.Encode(), "", nil)
+		if w.Code != tc.status {
+			t.Errorf("query %v: got status %d, want %d", tc.query, w.Code, tc.status)
+		}
+	}
+}
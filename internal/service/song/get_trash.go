@@ -0,0 +1,54 @@
+package song
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GetTrash                godoc
+// @Summary                Get deleted songs
+// @Description            Get songs in the trash, most recently deleted first, with pagination, default pagination value will be 3
+// @Tags                   Song
+// @Accept                 json
+// @Produce                json
+// @Param   	           page    query     int     false         "page number in pagination"
+// @Param  		           limit   query     int     false         "number of elements in one page, at most 100"
+// @Success      		   200    {object}  models.GetTrashResponse
+// @Failure      		   401    {object}  models.ErrorResponse
+// @Failure      		   403    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Security     		   BearerAuth
+// @Security     		   ApiKeyAuth
+// @Router       		   /trash [get]
+func (s *Service) GetTrash(ctx *gin.Context) {
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = models.DefaultPaginationPage
+	}
+
+	limit, err := strconv.Atoi(ctx.Query("limit"))
+	if err != nil || limit < 1 {
+		limit = models.DefaultPaginationSize
+	}
+	limit = min(limit, models.MaxPaginationSize)
+
+	songs, totalSongCount, err := s.Repo.GetTrash(ctx, page, limit)
+	if err != nil {
+		s.Logger.Info("song.GetTrash", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := models.GetTrashResponse{
+		Songs:          songs,
+		TotalSongCount: totalSongCount,
+		Page:           page,
+	}
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
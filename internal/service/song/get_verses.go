@@ -2,6 +2,8 @@ package song
 
 import (
 	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/pagination"
+	"github.com/LionJr/music-library/internal/service/respond"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"net/http"
@@ -15,8 +17,9 @@ import (
 // @Accept                 json
 // @Produce                json
 // @Param   	           id      path      int     true          "song id"
-// @Param   	           page    query     int     false         "page number in pagination"
-// @Param  		           limit   query     int     false         "number of elements in one page"
+// @Param   	           page    query     int     false         "page number in pagination, ignored when cursor is set"
+// @Param   	           cursor  query     string  false         "next_cursor or prev_cursor from a previous response"
+// @Param  		           limit   query     int     false         "number of elements in one page, at most 100"
 // @Success      		   200    {object}  models.GetSongVerseResponse
 // @Failure      		   400    {object}  models.ErrorResponse
 // @Failure      		   404    {object}  models.ErrorResponse
@@ -27,44 +30,51 @@ func (s *Service) GetVerses(ctx *gin.Context) {
 	songId, err := strconv.Atoi(idParam)
 	if err != nil || songId <= 0 {
 		s.Logger.Info("song.GetVerse: ", zap.String("id", idParam))
-		sendErrorResponse(ctx, "invalid song id", http.StatusBadRequest)
+		respond.Error(ctx, "invalid song id", http.StatusBadRequest)
 		return
 	}
 
 	exists, err := s.Repo.SongExists(ctx, songId)
 	if err != nil {
 		s.Logger.Info("song.GetVerse: ", zap.Error(err))
-		sendErrorResponse(ctx, "internal server error", http.StatusInternalServerError)
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
 		return
 	}
 
 	if !exists {
-		sendErrorResponse(ctx, "song does not exist", http.StatusNotFound)
+		respond.DomainError(ctx, models.ErrSongNotFound, "song does not exist", http.StatusNotFound)
 		return
 	}
 
-	page, err := strconv.Atoi(ctx.Query("page"))
-	if err != nil || page < 1 {
-		page = models.DefaultPaginationPage
-	}
-
-	limit, err := strconv.Atoi(ctx.Query("limit"))
-	if err != nil || limit < 1 {
-		limit = models.DefaultPaginationSize
+	scope := versesCursorScope(songId)
+	req, err := s.cursors.ParsePage(ctx, scope, "", false, nil)
+	if err != nil {
+		respond.Error(ctx, "invalid cursor", http.StatusBadRequest)
+		return
 	}
 
-	verses, totalVerseCount, err := s.Repo.GetSongVerses(ctx, songId, page, limit)
+	verses, totalVerseCount, err := s.Repo.GetSongVerses(ctx, songId, req.Page)
 	if err != nil {
 		s.Logger.Info("song.GetVerse", zap.Error(err))
-		sendErrorResponse(ctx, "internal server error", http.StatusInternalServerError)
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
 		return
 	}
 
+	verses, next, prev := pagination.Paginate(s.cursors, req, verses, scope, "", false,
+		func(verse *models.Verse) (string, int) { return strconv.Itoa(verse.Index), verse.Id },
+	)
+
 	resp := models.GetSongVerseResponse{
 		Verses:          verses,
 		TotalVerseCount: totalVerseCount,
-		Page:            page,
+		Page:            req.Number,
+		NextCursor:      next,
+		PrevCursor:      prev,
 	}
 
 	sendSuccessResponse(ctx, resp, http.StatusOK)
 }
+
+func versesCursorScope(songId int) string {
+	return "verses:" + strconv.Itoa(songId)
+}
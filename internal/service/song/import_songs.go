@@ -0,0 +1,345 @@
+package song
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/LionJr/music-library/internal/service/validate"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	importFormatCSV    = "csv"
+	importFormatJSON   = "json"
+	importFormatNDJSON = "ndjson"
+
+	// importBatchSize songs are added per transaction; a failed batch does
+	// not undo the ones before it and is retried song by song, so that only
+	// the songs that cannot be added fail.
+	importBatchSize = 100
+	maxImportSize   = 10 << 20
+)
+
+// importRow is a parsed row of an upload, or the reason it could not be
+// parsed.
+type importRow struct {
+	row models.ImportSongRow
+	err string
+}
+
+// Import                  godoc
+// @Summary                Bulk import of songs
+// @Description            Adding songs from a CSV, JSON or NDJSON upload with the fields group, song, release_date, link and text. The upload is either the request body or the "file" field of a multipart form; its format is taken from the format parameter, the file extension or the content type. Rows are validated like PATCH /songs/{id}, songs that already exist are skipped, and the response reports what happened to every row
+// @Tags                   Song
+// @Accept                 text/csv,application/json,application/x-ndjson,multipart/form-data
+// @Produce                json
+// @Param   	           format  query     string  false  "upload format"  Enums(csv, json, ndjson)
+// @Param   	           file    formData  file    false  "file to import, for multipart uploads"
+// @Success      		   200    {object}  models.ImportSongsResponse
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   401    {object}  models.ErrorResponse
+// @Failure      		   403    {object}  models.ErrorResponse
+// @Failure      		   413    {object}  models.ErrorResponse
+// @Security     		   BearerAuth
+// @Security     		   ApiKeyAuth
+// @Router       		   /songs/import [post]
+func (s *Service) Import(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportSize)
+
+	body, filename := io.Reader(ctx.Request.Body), ""
+	if ctx.ContentType() == "multipart/form-data" {
+		header, err := ctx.FormFile("file")
+		if err != nil {
+			s.Logger.Info("song.Import: read form file", zap.Error(err))
+			sendImportReadError(ctx, err, "file is required")
+			return
+		}
+
+		file, err := header.Open()
+		if err != nil {
+			s.Logger.Info("song.Import: open form file", zap.Error(err))
+			respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		defer file.Close()
+
+		body, filename = file, header.Filename
+	}
+
+	format := importFormat(ctx.Query("format"), filename, ctx.ContentType())
+	if format == "" {
+		respond.Error(ctx, "unknown upload format, use csv, json or ndjson", http.StatusBadRequest)
+		return
+	}
+
+	rows, err := parseImport(body, format)
+	if err != nil {
+		s.Logger.Info("song.Import: parse upload", zap.Error(err))
+		sendImportReadError(ctx, err, "invalid "+format+" upload")
+		return
+	}
+
+	resp := models.ImportSongsResponse{Rows: make([]models.ImportRowResult, len(rows))}
+
+	var (
+		batch     []models.Song
+		batchRows []int
+	)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		ids, err := s.Repo.ImportSongs(ctx, batch)
+		if err != nil {
+			s.Logger.Info("song.Import: batch failed, retrying song by song", zap.Error(err))
+			ids = s.importEach(ctx, batch)
+		}
+
+		for i, row := range batchRows {
+			switch {
+			case ids[i] < 0:
+				resp.Rows[row] = models.ImportRowResult{Status: models.ImportStatusFailed, Error: "song add error"}
+			case ids[i] == 0:
+				resp.Rows[row] = models.ImportRowResult{Status: models.ImportStatusSkipped, Error: "song already exists"}
+			default:
+				resp.Rows[row] = models.ImportRowResult{Status: models.ImportStatusCreated, SongID: ids[i]}
+			}
+		}
+
+		batch, batchRows = batch[:0], batchRows[:0]
+	}
+
+	for i, row := range rows {
+		if row.err == "" {
+			row.err = validateImportRow(&row.row)
+		}
+		if row.err != "" {
+			resp.Rows[i] = models.ImportRowResult{Status: models.ImportStatusFailed, Error: row.err}
+			continue
+		}
+
+		batch = append(batch, models.Song{
+			GroupName:   row.row.GroupName,
+			SongName:    row.row.SongName,
+			ReleaseDate: models.Date(row.row.ReleaseDate),
+			Link:        row.row.Link,
+			Text:        row.row.Text,
+		})
+		batchRows = append(batchRows, i)
+
+		if len(batch) == importBatchSize {
+			flush()
+		}
+	}
+	flush()
+
+	for i := range resp.Rows {
+		resp.Rows[i].Row = i + 1
+		switch resp.Rows[i].Status {
+		case models.ImportStatusCreated:
+			resp.Created++
+		case models.ImportStatusSkipped:
+			resp.Skipped++
+		default:
+			resp.Failed++
+		}
+	}
+
+	s.Logger.Info("song.Import: import finished",
+		zap.Int("created", resp.Created), zap.Int("skipped", resp.Skipped), zap.Int("failed", resp.Failed))
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
+
+// importEach adds songs in a transaction each, after the batch they were in
+// failed. The id of a song that cannot be added is -1.
+func (s *Service) importEach(ctx context.Context, songs []models.Song) []int {
+	ids := make([]int, len(songs))
+	for i := range songs {
+		added, err := s.Repo.ImportSongs(ctx, songs[i:i+1])
+		if err != nil {
+			s.Logger.Info("song.Import: ", zap.String("group", songs[i].GroupName), zap.String("song", songs[i].SongName), zap.Error(err))
+			ids[i] = -1
+			continue
+		}
+		ids[i] = added[0]
+	}
+
+	return ids
+}
+
+func sendImportReadError(ctx *gin.Context, err error, msg string) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		respond.Error(ctx, fmt.Sprintf("upload is larger than %d bytes", tooLarge.Limit), http.StatusRequestEntityTooLarge)
+		return
+	}
+	respond.Error(ctx, msg, http.StatusBadRequest)
+}
+
+// importFormat picks the upload format from the format parameter, then the
+// file extension, then the content type.
+func importFormat(param, filename, contentType string) string {
+	switch strings.ToLower(param) {
+	case importFormatCSV, importFormatJSON, importFormatNDJSON:
+		return strings.ToLower(param)
+	case "":
+	default:
+		return ""
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return importFormatCSV
+	case ".json":
+		return importFormatJSON
+	case ".ndjson", ".jsonl":
+		return importFormatNDJSON
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv":
+		return importFormatCSV
+	case "application/json":
+		return importFormatJSON
+	case "application/x-ndjson", "application/jsonl":
+		return importFormatNDJSON
+	default:
+		return ""
+	}
+}
+
+// parseImport reads the rows of an upload. Rows that cannot be decoded are
+// returned with an error so the rest of the upload is still imported; only
+// an unreadable upload as a whole is an error.
+func parseImport(r io.Reader, format string) ([]importRow, error) {
+	switch format {
+	case importFormatCSV:
+		return parseImportCSV(r)
+	case importFormatJSON:
+		var raw []json.RawMessage
+		if err := json.NewDecoder(r).Decode(&raw); err != nil {
+			return nil, err
+		}
+
+		rows := make([]importRow, 0, len(raw))
+		for _, item := range raw {
+			rows = append(rows, decodeImportRow(item))
+		}
+		return rows, nil
+	default:
+		var rows []importRow
+
+		scanner := bufio.NewScanner(r)
+		// A line may be as long as the upload, so that a too large upload
+		// fails on the size limit rather than the line length.
+		scanner.Buffer(nil, maxImportSize+1)
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) > 0 {
+				rows = append(rows, decodeImportRow(line))
+			}
+		}
+		return rows, scanner.Err()
+	}
+}
+
+func decodeImportRow(data []byte) importRow {
+	var row importRow
+	if err := json.Unmarshal(data, &row.row); err != nil {
+		row.err = "invalid json row"
+	}
+	return row
+}
+
+func parseImportCSV(r io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\uFEFF")))] = i
+	}
+	for _, name := range []string{"group", "song"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("csv header has no %q column", name)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, importRow{err: "invalid csv row: " + parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, importRow{row: models.ImportSongRow{
+			GroupName:   field(record, "group"),
+			SongName:    field(record, "song"),
+			ReleaseDate: field(record, "release_date"),
+			Link:        field(record, "link"),
+			Text:        field(record, "text"),
+		}})
+	}
+}
+
+// validateImportRow applies the rules of validateInput to a row and cleans
+// it up in place. Empty release dates and links are allowed.
+func validateImportRow(row *models.ImportSongRow) string {
+	input := models.EditSongRequest{
+		GroupName: &row.GroupName,
+		SongName:  &row.SongName,
+	}
+	if row.ReleaseDate != "" {
+		input.ReleaseDate = &row.ReleaseDate
+	}
+	if row.Link != "" {
+		input.Link = &row.Link
+	}
+
+	validationErrors := validate.Errors(validateInput(&input))
+	validationErrors.Text("text", "song text", &row.Text, false)
+
+	messages := make([]string, len(validationErrors))
+	for i, e := range validationErrors {
+		messages[i] = e.Message
+	}
+
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,189 @@
+package song_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/LionJr/music-library/config"
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/repository/memory"
+	"github.com/LionJr/music-library/internal/service/song"
+)
+
+func importSongs(t *testing.T, s *song.Service, target, body string, header http.Header) models.ImportSongsResponse {
+	t.Helper()
+
+	w := serve(s.Import, http.MethodPost, target, body, header)
+	if w.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	var resp models.ImportSongsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return resp
+}
+
+func statuses(resp models.ImportSongsResponse) []string {
+	out := make([]string, len(resp.Rows))
+	for i, row := range resp.Rows {
+		out[i] = row.Status
+	}
+	return out
+}
+
+func TestImportFormats(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		target string
+		header http.Header
+		body   string
+	}{
+		{
+			"csv", "/songs/import", http.Header{"Content-Type": {"text/csv"}},
+			"\uFEFFGroup,song,release_date,link,text\n" +
+				"Muse,Uprising,2009-09-07,https://example.com/uprising,\"Paranoia is in bloom\"\n" +
+				"Muse,Starlight,16.07.2006,,\n" +
+				"Muse,\"broken\n",
+		},
+		{
+			"json", "/songs/import?format=json", nil,
+			`[{"group":"Muse","song":"Uprising","release_date":"2009-09-07"},{"group":"Muse","song":"Starlight"},{"group":1}]`,
+		},
+		{
+			"ndjson", "/songs/import", http.Header{"Content-Type": {"application/x-ndjson"}},
+			"{\"group\":\"Muse\",\"song\":\"Uprising\"}\n\n{\"group\":\"Muse\",\"song\":\"Starlight\"}\r\nnot json\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := importSongs(t, newTestService(&fakeMetadata{}), tc.target, tc.body, tc.header)
+
+			got := statuses(resp)
+			want := []string{models.ImportStatusCreated, models.ImportStatusCreated, models.ImportStatusFailed}
+			if strings.Join(got, " ") != strings.Join(want, " ") {
+				t.Fatalf("got statuses %v, want %v: %+v", got, want, resp.Rows)
+			}
+			if resp.Created != 2 || resp.Failed != 1 || resp.Rows[2].Row != 3 || resp.Rows[2].Error == "" {
+				t.Errorf("got %+v, want 2 created and row 3 failed with an error", resp)
+			}
+		})
+	}
+}
+
+func TestImportMultipart(t *testing.T) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	file, _ := form.CreateFormFile("file", "songs.ndjson")
+	_, _ = file.Write([]byte(`{"group":"Muse","song":"Uprising"}`))
+	_ = form.Close()
+
+	resp := importSongs(t, newTestService(&fakeMetadata{}), "/songs/import", body.String(),
+		http.Header{"Content-Type": {form.FormDataContentType()}})
+	if resp.Created != 1 {
+		t.Errorf("got %+v, want one created song", resp)
+	}
+}
+
+func TestImportRowResults(t *testing.T) {
+	s := newTestService(&fakeMetadata{})
+	if _, err := s.Repo.Add(t.Context(), &models.Song{GroupName: "Muse", SongName: "Uprising"}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	body := `[
+		{"group":"Muse","song":"Uprising"},
+		{"group":"Muse","song":"Starlight","release_date":"someday"},
+		{"group":"","song":"Nameless"},
+		{"group":"Muse","song":"Hysteria","link":"https://example.com/` + strings.Repeat("é", 300) + `"},
+		{"group":"Muse","song":"Resistance"},
+		{"group":"Muse","song":"Resistance"}
+	]`
+	resp := importSongs(t, s, "/songs/import?format=json", body, nil)
+
+	want := []string{
+		models.ImportStatusSkipped,
+		models.ImportStatusFailed,
+		models.ImportStatusFailed,
+		models.ImportStatusFailed,
+		models.ImportStatusCreated,
+		models.ImportStatusSkipped,
+	}
+	if got := statuses(resp); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("got statuses %v, want %v: %+v", got, want, resp.Rows)
+	}
+	if resp.Created != 1 || resp.Skipped != 2 || resp.Failed != 3 {
+		t.Errorf("got %d created, %d skipped, %d failed, want 1, 2, 3", resp.Created, resp.Skipped, resp.Failed)
+	}
+	for i, row := range resp.Rows {
+		if row.Row != i+1 {
+			t.Errorf("row %d: got number %d", i, row.Row)
+		}
+	}
+}
+
+func TestImportRejectsUploads(t *testing.T) {
+	s := newTestService(&fakeMetadata{})
+
+	for _, tc := range []struct {
+		name   string
+		target string
+		header http.Header
+		body   string
+		status int
+	}{
+		{"unknown format", "/songs/import", http.Header{"Content-Type": {"text/plain"}}, "x", http.StatusBadRequest},
+		{"csv without song column", "/songs/import?format=csv", nil, "group\nMuse\n", http.StatusBadRequest},
+		{"json that is no array", "/songs/import?format=json", nil, `{"group":"Muse"}`, http.StatusBadRequest},
+		{"too large", "/songs/import?format=ndjson", nil, strings.Repeat(" ", 10<<20+1), http.StatusRequestEntityTooLarge},
+		{"too many lines", "/songs/import?format=ndjson", nil, strings.Repeat(`{"text":"`+strings.Repeat("a", 1<<10)+"\"}\n", 10<<10), http.StatusRequestEntityTooLarge},
+		{"too large csv", "/songs/import?format=csv", nil, "group,song\n" + strings.Repeat("a,"+strings.Repeat("b", 1<<10)+"\n", 10<<10), http.StatusRequestEntityTooLarge},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if w := serve(s.Import, http.MethodPost, tc.target, tc.body, tc.header); w.Code != tc.status {
+				t.Errorf("got status %d, want %d: %s", w.Code, tc.status, w.Body)
+			}
+		})
+	}
+}
+
+// failingRepo fails every import that includes a song named "boom".
+type failingRepo struct {
+	song.Repo
+}
+
+func (r failingRepo) ImportSongs(ctx context.Context, songs []models.Song) ([]int, error) {
+	for _, s := range songs {
+		if s.SongName == "boom" {
+			return nil, errors.New("boom")
+		}
+	}
+	return r.Repo.ImportSongs(ctx, songs)
+}
+
+func TestImportRetriesFailedBatchSongBySong(t *testing.T) {
+	repo := failingRepo{memory.NewSongRepository(memory.NewStorage())}
+	s := song.NewService(&config.AppConfig{}, zap.NewNop(), repo, &fakeMetadata{})
+
+	body := `{"group":"Muse","song":"Uprising"}
+{"group":"Muse","song":"boom"}
+{"group":"Muse","song":"Uprising"}
+{"group":"Muse","song":"Starlight"}`
+	resp := importSongs(t, s, "/songs/import?format=ndjson", body, nil)
+
+	want := []string{models.ImportStatusCreated, models.ImportStatusFailed, models.ImportStatusSkipped, models.ImportStatusCreated}
+	if got := statuses(resp); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("got statuses %v, want %v: %+v", got, want, resp.Rows)
+	}
+	if _, total, err := repo.GetSongs(t.Context(), &models.SongFilter{}, &models.Page{Limit: 10}); err != nil || total != 2 {
+		t.Errorf("got %d songs (error %v), want 2", total, err)
+	}
+}
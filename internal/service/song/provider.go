@@ -0,0 +1,14 @@
+package song
+
+import (
+	"context"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+type MetadataProvider interface {
+	// FetchSongDetail returns songinfo.ErrNotFound for a song the provider
+	// does not know and a songinfo.CircuitOpenError while it is considered
+	// down.
+	FetchSongDetail(ctx context.Context, group, song string) (*models.SongDetail, error)
+}
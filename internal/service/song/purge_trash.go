@@ -0,0 +1,40 @@
+package song
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RunTrashPurge permanently removes songs that have been in the trash for
+// longer than the configured retention, checking every purge interval until
+// ctx is done. It returns at once when the retention is zero.
+func (s *Service) RunTrashPurge(ctx context.Context) {
+	retention, interval := s.config.Trash.Retention, s.config.Trash.PurgeInterval
+	if retention <= 0 {
+		return
+	}
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := s.Repo.PurgeTrash(ctx, retention)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			s.Logger.Error("song.RunTrashPurge: ", zap.Error(err))
+		case purged > 0:
+			s.Logger.Info("song.RunTrashPurge: purged deleted songs", zap.Int("count", purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package song
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ReorderVerses godoc
+// @Summary      	     Reorder song verses
+// @Description  	     Put the verses of a song in a new order. The order lists the current index of every verse, e.g. [2, 1, 3] swaps the first two verses
+// @Tags         	     Song
+// @Accept       	     json
+// @Produce      	     json
+// @Param 			     id 	             path      integer                     true   "song id"
+// @Param 			     req 	             body      models.ReorderVersesRequest true   "new verse order"
+// @Success      	     200  		         {object}  string
+// @Failure      	     400  			     {object}  models.ErrorResponse
+// @Failure      	     401  			     {object}  models.ErrorResponse
+// @Failure      	     403  			     {object}  models.ErrorResponse
+// @Failure      	     404  			     {object}  models.ErrorResponse
+// @Failure      	     500  			     {object}  models.ErrorResponse
+// @Security     	     BearerAuth
+// @Security     	     ApiKeyAuth
+// @Router       	     /songs/{id}/verses/reorder [post]
+func (s *Service) ReorderVerses(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	songId, err := strconv.Atoi(idParam)
+	if err != nil || songId <= 0 {
+		s.Logger.Info("song.ReorderVerses: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid song id", http.StatusBadRequest)
+		return
+	}
+
+	var req models.ReorderVersesRequest
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		s.Logger.Info("song.ReorderVerses: unmarshal request body", zap.Error(err))
+		respond.Error(ctx, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	exists, err := s.Repo.SongExists(ctx, songId)
+	if err != nil {
+		s.Logger.Info("song.ReorderVerses: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		respond.DomainError(ctx, models.ErrSongNotFound, "song does not exist", http.StatusNotFound)
+		return
+	}
+
+	err = s.Repo.ReorderVerses(ctx, songId, req.Order)
+	if err != nil {
+		s.Logger.Info("song.ReorderVerses: ", zap.Error(err))
+		switch {
+		case errors.Is(err, models.ErrInvalidVerseOrder):
+			respond.DomainError(ctx, err, "order must list every verse index once", http.StatusBadRequest)
+		case errors.Is(err, models.ErrSongNotFound):
+			respond.DomainError(ctx, err, "song does not exist", http.StatusNotFound)
+		default:
+			respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	sendSuccessResponse(ctx, "Successfully reordered", http.StatusOK)
+}
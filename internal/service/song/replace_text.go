@@ -0,0 +1,84 @@
+package song
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/LionJr/music-library/internal/service/validate"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ReplaceText godoc
+// @Summary      	     Replace song lyrics
+// @Description  	     Replace all verses of a song with the given lyrics, split into verses on empty lines like a newly added song
+// @Tags         	     Song
+// @Accept       	     json
+// @Produce      	     json
+// @Param 			     id 	             path      integer                    true   "song id"
+// @Param 			     req 	             body      models.ReplaceTextRequest  true   "complete song lyrics"
+// @Success      	     200  		         {object}  models.ReplaceTextResponse
+// @Failure      	     400  			     {object}  models.ErrorResponse
+// @Failure      	     401  			     {object}  models.ErrorResponse
+// @Failure      	     403  			     {object}  models.ErrorResponse
+// @Failure      	     404  			     {object}  models.ErrorResponse
+// @Failure      	     500  			     {object}  models.ErrorResponse
+// @Security     	     BearerAuth
+// @Security     	     ApiKeyAuth
+// @Router       	     /songs/{id}/text [put]
+func (s *Service) ReplaceText(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	songId, err := strconv.Atoi(idParam)
+	if err != nil || songId <= 0 {
+		s.Logger.Info("song.ReplaceText: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid song id", http.StatusBadRequest)
+		return
+	}
+
+	var req models.ReplaceTextRequest
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		s.Logger.Info("song.ReplaceText: unmarshal request body", zap.Error(err))
+		respond.Error(ctx, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var validationErrors validate.Errors
+	validationErrors.Text("text", "song text", &req.Text, true)
+	if len(validationErrors) > 0 {
+		respond.ValidationErrors(ctx, validationErrors...)
+		return
+	}
+
+	exists, err := s.Repo.SongExists(ctx, songId)
+	if err != nil {
+		s.Logger.Info("song.ReplaceText: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		respond.DomainError(ctx, models.ErrSongNotFound, "song does not exist", http.StatusNotFound)
+		return
+	}
+
+	count, err := s.Repo.ReplaceText(ctx, songId, req.Text)
+	if err != nil {
+		s.Logger.Info("song.ReplaceText: ", zap.Error(err))
+		if errors.Is(err, models.ErrSongNotFound) {
+			respond.DomainError(ctx, err, "song does not exist", http.StatusNotFound)
+		} else {
+			respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	resp := models.ReplaceTextResponse{
+		Message:    "Song text successfully replaced",
+		VerseCount: count,
+	}
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
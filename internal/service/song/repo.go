@@ -2,15 +2,72 @@ package song
 
 import (
 	"context"
+	"time"
+
 	"github.com/LionJr/music-library/internal/models"
 )
 
 type Repo interface {
+	// Add adds a song and returns its id, or models.ErrSongExists when its
+	// group already has a song of that name.
 	Add(ctx context.Context, song *models.Song) (int, error)
+	// ImportSongs adds songs in one transaction, skipping songs whose group
+	// already has a song with the same name, including earlier ones of the
+	// same call. It returns the id of each added song and 0 for skipped ones.
+	ImportSongs(ctx context.Context, songs []models.Song) ([]int, error)
+	// AddVerse inserts a verse at position, moving the verses from there on
+	// down by one; position 0 appends. It returns the index of the verse.
+	AddVerse(ctx context.Context, songId, position int, text string) (int, error)
+	// Delete moves a song to the trash, which hides it from every read,
+	// albums and playlists included, until it is restored or purged.
 	Delete(ctx context.Context, id int) error
-	Edit(ctx context.Context, id int, input *models.EditSongRequest) error
-	GetSongs(ctx context.Context, group, song string, page, limit int) ([]models.Song, int, error)
-	GetSongVerses(ctx context.Context, songId, page, limit int) ([]models.Verse, int, error)
+	// DeleteVerse removes a verse and moves the verses after it up by one.
+	DeleteVerse(ctx context.Context, songId, index int) error
+	// Edit changes the fields of input that are set and returns the new
+	// version of the song. A version other than 0 must match the current
+	// one, otherwise models.ErrVersionMismatch is returned. It returns
+	// models.ErrVerseNotFound for an unknown verse and models.ErrSongExists
+	// when the group has another song of the new name.
+	Edit(ctx context.Context, id int, input *models.EditSongRequest, version int) (int, error)
+	// ExportSongs calls emit with every song matching filter, in the order
+	// of GetSongs, together with its verses. It reads the songs in batches
+	// and stops at the first error emit returns.
+	ExportSongs(ctx context.Context, filter *models.SongFilter, emit func(*models.SongExport) error) error
+	// GetSong returns a song with its text joined from its verses, or
+	// models.ErrSongNotFound.
+	GetSong(ctx context.Context, id int) (*models.Song, error)
+	GetSongs(ctx context.Context, filter *models.SongFilter, page *models.Page) ([]models.Song, int, error)
+	// GetTrash lists the songs in the trash, most recently deleted first.
+	GetTrash(ctx context.Context, page, limit int) ([]models.Song, int, error)
+	GetSongVerses(ctx context.Context, songId int, page *models.Page) ([]models.Verse, int, error)
+	// GetRevision returns one revision of a song, or
+	// models.ErrRevisionNotFound.
+	GetRevision(ctx context.Context, songId, revision int) (*models.SongRevision, error)
+	// GetRevisions lists the revisions of a song, newest first. Every change
+	// of a song or its verses is recorded as a revision with the actor of the
+	// context and the song before and after the change.
+	GetRevisions(ctx context.Context, songId, page, limit int) ([]models.SongRevision, int, error)
+	// PurgeTrash permanently removes the songs that were deleted more than
+	// olderThan ago, together with their verses, album tracks and playlist
+	// items, and returns their number.
+	PurgeTrash(ctx context.Context, olderThan time.Duration) (int, error)
+	// ReorderVerses renumbers the verses of a song: order lists the current
+	// index of every verse in its new place.
+	ReorderVerses(ctx context.Context, songId int, order []int) error
+	// ReplaceText replaces all verses of a song with the verses of text, split
+	// like Add does, and returns the new verse count.
+	ReplaceText(ctx context.Context, songId int, text string) (int, error)
+	// RestoreSong takes a song out of the trash. It returns
+	// models.ErrSongNotFound when the song is not in the trash and
+	// models.ErrSongExists when its group got another song of the same name
+	// in the meantime.
+	RestoreSong(ctx context.Context, id int) error
+	// RestoreRevision brings a song and its verses back to their state at
+	// revision and returns the number of the revision recording that. It
+	// returns models.ErrRevisionNotFound for an unknown revision and
+	// models.ErrSongExists when the group has another song of that name.
+	RestoreRevision(ctx context.Context, songId, revision int) (int, error)
+	SearchVerses(ctx context.Context, query string, page, limit int) ([]models.VerseSearchResult, int, error)
 	SongExists(ctx context.Context, id int) (bool, error)
 	VerseExists(ctx context.Context, songId, index int) (bool, error)
 }
@@ -0,0 +1,83 @@
+package song
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RestoreRevision godoc
+// @Summary      	     Restore song revision
+// @Description  	     Bring a song and its verses back to their state at a revision. The rollback is recorded as a new revision
+// @Tags         	     Song
+// @Accept       	     json
+// @Produce      	     json
+// @Param 			     id 	             path      integer                true   "song id"
+// @Param 			     rev 	             path      integer                true   "revision"
+// @Success      	     200  		         {object}  models.RestoreRevisionResponse
+// @Failure      	     400  			     {object}  models.ErrorResponse
+// @Failure      	     401  			     {object}  models.ErrorResponse
+// @Failure      	     403  			     {object}  models.ErrorResponse
+// @Failure      	     404  			     {object}  models.ErrorResponse
+// @Failure      	     409  			     {object}  models.ErrorResponse
+// @Failure      	     500  			     {object}  models.ErrorResponse
+// @Security     	     BearerAuth
+// @Security     	     ApiKeyAuth
+// @Router       	     /songs/{id}/revisions/{rev}/restore [post]
+func (s *Service) RestoreRevision(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	songId, err := strconv.Atoi(idParam)
+	if err != nil || songId <= 0 {
+		s.Logger.Info("song.RestoreRevision: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid song id", http.StatusBadRequest)
+		return
+	}
+
+	revParam := ctx.Param("rev")
+	revision, err := strconv.Atoi(revParam)
+	if err != nil || revision <= 0 {
+		s.Logger.Info("song.RestoreRevision: ", zap.String("rev", revParam))
+		respond.Error(ctx, "invalid revision", http.StatusBadRequest)
+		return
+	}
+
+	exists, err := s.Repo.SongExists(ctx, songId)
+	if err != nil {
+		s.Logger.Info("song.RestoreRevision: ", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		respond.DomainError(ctx, models.ErrSongNotFound, "song does not exist", http.StatusNotFound)
+		return
+	}
+
+	next, err := s.Repo.RestoreRevision(ctx, songId, revision)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrSongNotFound):
+			respond.DomainError(ctx, err, "song does not exist", http.StatusNotFound)
+		case errors.Is(err, models.ErrRevisionNotFound):
+			respond.DomainError(ctx, err, "revision does not exist", http.StatusNotFound)
+		case errors.Is(err, models.ErrSongExists):
+			respond.DomainError(ctx, err, "group already has a song with this name", http.StatusConflict)
+		default:
+			s.Logger.Info("song.RestoreRevision: ", zap.Error(err))
+			respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	resp := models.RestoreRevisionResponse{
+		Message:  "Successfully restored",
+		Revision: next,
+	}
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
@@ -0,0 +1,55 @@
+package song
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Restore godoc
+// @Summary      	     Restore deleted song
+// @Description  	     Take a song out of the trash by song id
+// @Tags         	     Song
+// @Accept       	     json
+// @Produce      	     json
+// @Param 			     id 	             path      integer                true   "song id"
+// @Success      	     200  		         {object}  string
+// @Failure      	     400  			     {object}  models.ErrorResponse
+// @Failure      	     401  			     {object}  models.ErrorResponse
+// @Failure      	     403  			     {object}  models.ErrorResponse
+// @Failure      	     404  			     {object}  models.ErrorResponse
+// @Failure      	     409  			     {object}  models.ErrorResponse
+// @Failure      	     500  			     {object}  models.ErrorResponse
+// @Security     	     BearerAuth
+// @Security     	     ApiKeyAuth
+// @Router       	     /songs/{id}/restore [post]
+func (s *Service) Restore(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	songId, err := strconv.Atoi(idParam)
+	if err != nil || songId <= 0 {
+		s.Logger.Info("song.Restore: ", zap.String("id", idParam))
+		respond.Error(ctx, "invalid song id", http.StatusBadRequest)
+		return
+	}
+
+	err = s.Repo.RestoreSong(ctx, songId)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrSongNotFound):
+			respond.DomainError(ctx, err, "song is not in the trash", http.StatusNotFound)
+		case errors.Is(err, models.ErrSongExists):
+			respond.DomainError(ctx, err, "group already has a song with this name", http.StatusConflict)
+		default:
+			s.Logger.Info("song.Restore: ", zap.Error(err))
+			respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	sendSuccessResponse(ctx, "Successfully restored", http.StatusOK)
+}
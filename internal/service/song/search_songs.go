@@ -0,0 +1,59 @@
+package song
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/respond"
+	"github.com/LionJr/music-library/internal/service/validate"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Search                  godoc
+// @Summary                Search lyrics
+// @Description            Full-text search over song verses, ranked by relevance, with matches highlighted in <b></b>. Default pagination value will be 3
+// @Tags                   Song
+// @Accept                 json
+// @Produce                json
+// @Param   	           q       query     string  true        "words to search for, all of them must appear in the verse"
+// @Param   	           page    query     int     false       "page number in pagination"
+// @Param  		           limit   query     int     false       "number of elements in one page, at most 100"
+// @Success      		   200    {object}  models.SearchSongsResponse
+// @Failure      		   400    {object}  models.ErrorResponse
+// @Failure      		   500    {object}  models.ErrorResponse
+// @Router       		   /songs/search [get]
+func (s *Service) Search(ctx *gin.Context) {
+	query := validate.Query(ctx.Query("q"))
+	if query == "" {
+		respond.Error(ctx, "search query is required", http.StatusBadRequest)
+		return
+	}
+
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = models.DefaultPaginationPage
+	}
+
+	limit, err := strconv.Atoi(ctx.Query("limit"))
+	if err != nil || limit < 1 {
+		limit = models.DefaultPaginationSize
+	}
+	limit = min(limit, models.MaxPaginationSize)
+
+	results, totalCount, err := s.Repo.SearchVerses(ctx, query, page, limit)
+	if err != nil {
+		s.Logger.Info("song.Search", zap.Error(err))
+		respond.Error(ctx, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := models.SearchSongsResponse{
+		Results:    results,
+		TotalCount: totalCount,
+		Page:       page,
+	}
+
+	sendSuccessResponse(ctx, resp, http.StatusOK)
+}
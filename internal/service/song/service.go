@@ -2,6 +2,7 @@ package song
 
 import (
 	"github.com/LionJr/music-library/config"
+	"github.com/LionJr/music-library/internal/service/pagination"
 	"go.uber.org/zap"
 )
 
@@ -9,14 +10,20 @@ type Service struct {
 	config *config.AppConfig
 	Logger *zap.Logger
 
-	Repo Repo
+	Repo     Repo
+	Metadata MetadataProvider
+
+	cursors *pagination.Signer
 }
 
-func NewService(cfg *config.AppConfig, logger *zap.Logger, repo Repo) *Service {
+func NewService(cfg *config.AppConfig, logger *zap.Logger, repo Repo, metadata MetadataProvider) *Service {
 	return &Service{
 		config: cfg,
 		Logger: logger,
 
-		Repo: repo,
+		Repo:     repo,
+		Metadata: metadata,
+
+		cursors: pagination.NewSigner(cfg.Pagination.CursorSecret),
 	}
 }
@@ -0,0 +1,64 @@
+package song_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/LionJr/music-library/config"
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/repository/memory"
+	"github.com/LionJr/music-library/internal/service/song"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// fakeMetadata stands in for the song info provider.
+type fakeMetadata struct {
+	detail *models.SongDetail
+	err    error
+}
+
+func (f *fakeMetadata) FetchSongDetail(context.Context, string, string) (*models.SongDetail, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.detail == nil {
+		return &models.SongDetail{}, nil
+	}
+	detail := *f.detail
+	return &detail, nil
+}
+
+func newTestService(metadata song.MetadataProvider) *song.Service {
+	return song.NewService(&config.AppConfig{}, zap.NewNop(), memory.NewSongRepository(memory.NewStorage()), metadata)
+}
+
+// serve sends one request to handler and returns the recorded response.
+func serve(handler gin.HandlerFunc, method, target, body string, header http.Header) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+
+	req := httptest.NewRequest(method, target, reader)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	handler(ctx)
+	// gin writes the status of responses without a body after the handlers.
+	ctx.Writer.WriteHeaderNow()
+
+	return w
+}
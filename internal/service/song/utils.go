@@ -1,24 +1,61 @@
 package song
 
 import (
-	"github.com/LionJr/music-library/internal/models"
 	"github.com/gin-gonic/gin"
-	"regexp"
+	"strconv"
+	"strings"
 )
 
-func sendErrorResponse(ctx *gin.Context, msg string, status int) {
-	resp := models.ErrorResponse{
-		Message: msg,
-	}
-
-	ctx.JSON(status, resp)
-}
+// Column limits of the songs and groups tables.
+const (
+	maxGroupNameLength = 100
+	maxSongNameLength  = 200
+	maxLinkLength      = 255
+)
 
 func sendSuccessResponse(ctx *gin.Context, data interface{}, status int) {
 	ctx.JSON(status, data)
 }
 
-func sanitizeForSQL(input string) string {
-	re := regexp.MustCompile(`[^\w\s.,а-яА-Я]`)
-	return re.ReplaceAllString(input, "")
+// songETag turns the version of a song into its ETag.
+func songETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// songTextETag turns the version of a song into the ETag of its text alone.
+func songTextETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version) + "-text")
+}
+
+// etagListMatches reports whether an If-Match or If-None-Match header, "*" or
+// a list of entity tags, matches etag. The weak comparison of RFC 9110
+// ignores W/ prefixes; the strong one, used when strong is set, never matches
+// a weak tag. A malformed list matches nothing from the first bad tag on.
+func etagListMatches(header, etag string, strong bool) bool {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return true
+	}
+
+	for {
+		header = strings.TrimLeft(header, " \t,")
+		if header == "" {
+			return false
+		}
+
+		weak := strings.HasPrefix(header, "W/")
+		header = strings.TrimPrefix(header, "W/")
+		if !strings.HasPrefix(header, `"`) {
+			return false
+		}
+
+		end := strings.IndexByte(header[1:], '"')
+		if end < 0 {
+			return false
+		}
+		if header[:end+2] == etag && !(strong && weak) {
+			return true
+		}
+		header = header[end+2:]
+	}
 }
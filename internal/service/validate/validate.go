@@ -0,0 +1,87 @@
+// Package validate checks the text of requests before it is stored. Text is
+// kept as it was sent, apart from surrounding white space and normalisation
+// to NFC, and every problem is reported as a field error instead of being
+// fixed silently.
+package validate
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/LionJr/music-library/internal/models"
+)
+
+// Errors collects the problems of the fields of one request.
+type Errors []models.FieldError
+
+// Add records a problem of field.
+func (e *Errors) Add(field, message string) {
+	*e = append(*e, models.FieldError{Field: field, Message: message})
+}
+
+// Name normalises a single-line value such as a song title in place and
+// checks that it is not empty, has at most maxLength characters and no
+// control characters. label names the value in messages.
+func (e *Errors) Name(field, label string, value *string, maxLength int) {
+	if !utf8.ValidString(*value) {
+		e.Add(field, label+" is not valid UTF-8")
+		return
+	}
+
+	*value = norm.NFC.String(strings.TrimSpace(*value))
+
+	switch {
+	case *value == "":
+		e.Add(field, label+" is required")
+	case utf8.RuneCountInString(*value) > maxLength:
+		e.Add(field, fmt.Sprintf("%s is longer than %d characters", label, maxLength))
+	case strings.IndexFunc(*value, unicode.IsControl) >= 0:
+		e.Add(field, label+" must not contain control characters")
+	}
+}
+
+// Text normalises multi-line text such as lyrics in place and checks that it
+// has no control characters other than line breaks and tabs. Line breaks
+// become "\n". Text that is only white space counts as empty.
+func (e *Errors) Text(field, label string, value *string, required bool) {
+	if !utf8.ValidString(*value) {
+		e.Add(field, label+" is not valid UTF-8")
+		return
+	}
+
+	*value = strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(norm.NFC.String(*value))
+
+	switch {
+	case required && strings.TrimSpace(*value) == "":
+		e.Add(field, label+" is required")
+	case strings.IndexFunc(*value, isTextControl) >= 0:
+		e.Add(field, label+" must not contain control characters")
+	}
+}
+
+// Link checks that value is an http or https URL of at most maxLength
+// characters. label names the value in messages.
+func (e *Errors) Link(field, label, value string, maxLength int) {
+	parsedURL, err := url.ParseRequestURI(value)
+	switch {
+	case !utf8.ValidString(value) || err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https"):
+		e.Add(field, "invalid "+label)
+	case utf8.RuneCountInString(value) > maxLength:
+		e.Add(field, fmt.Sprintf("%s is longer than %d characters", label, maxLength))
+	}
+}
+
+// Query normalises a search term the way Name normalises stored values, so
+// that the two compare equal.
+func Query(value string) string {
+	return norm.NFC.String(strings.TrimSpace(value))
+}
+
+func isTextControl(r rune) bool {
+	return r != '\n' && r != '\t' && unicode.IsControl(r)
+}
@@ -0,0 +1,125 @@
+package validate_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/LionJr/music-library/internal/models"
+	"github.com/LionJr/music-library/internal/service/validate"
+)
+
+func TestName(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		value   string
+		want    string
+		message string
+	}{
+		{"ascii", "Muse", "Muse", ""},
+		{"apostrophe", "Guns N' Roses", "Guns N' Roses", ""},
+		{"composed", "Beyonc\u00e9", "Beyonc\u00e9", ""},
+		{"decomposed", "Beyonce\u0301", "Beyonc\u00e9", ""},
+		{"other script", "Кино", "Кино", ""},
+		{"surrounding space", "  Muse\t", "Muse", ""},
+		{"empty", "", "", "group name is required"},
+		{"only space", " \t ", "", "group name is required"},
+		{"at the limit", strings.Repeat("\u00e9", 20), strings.Repeat("\u00e9", 20), ""},
+		{"too long", strings.Repeat("\u00e9", 21), strings.Repeat("\u00e9", 21), "group name is longer than 20 characters"},
+		{"decomposed at the limit", strings.Repeat("e\u0301", 20), strings.Repeat("\u00e9", 20), ""},
+		{"line break", "Guns\nRoses", "Guns\nRoses", "group name must not contain control characters"},
+		{"tab", "Guns\tRoses", "Guns\tRoses", "group name must not contain control characters"},
+		{"nul", "Guns\x00Roses", "Guns\x00Roses", "group name must not contain control characters"},
+		{"C1 control", "Guns\u0085Roses", "Guns\u0085Roses", "group name must not contain control characters"},
+		{"invalid UTF-8", "Guns \xff Roses", "Guns \xff Roses", "group name is not valid UTF-8"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var errs validate.Errors
+			value := tc.value
+			errs.Name("group", "group name", &value, 20)
+
+			if value != tc.want {
+				t.Errorf("got value %q, want %q", value, tc.want)
+			}
+			checkErrors(t, errs, "group", tc.message)
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		value    string
+		required bool
+		want     string
+		message  string
+	}{
+		{"plain", "Ooh baby", true, "Ooh baby", ""},
+		{"kept as sent", "  Ooh baby\n\n", true, "  Ooh baby\n\n", ""},
+		{"tabs", "Ooh\tbaby", true, "Ooh\tbaby", ""},
+		{"CRLF", "Ooh\r\nbaby", true, "Ooh\nbaby", ""},
+		{"CR", "Ooh\rbaby", true, "Ooh\nbaby", ""},
+		{"decomposed", "Beyonce\u0301", true, "Beyonc\u00e9", ""},
+		{"empty", "", true, "", "verse text is required"},
+		{"only space", " \r\n\t", true, " \n\t", "verse text is required"},
+		{"empty, optional", "", false, "", ""},
+		{"nul", "Ooh\x00baby", false, "Ooh\x00baby", "verse text must not contain control characters"},
+		{"escape", "Ooh\x1b[31mbaby", false, "Ooh\x1b[31mbaby", "verse text must not contain control characters"},
+		{"invalid UTF-8", "Ooh \xff baby", false, "Ooh \xff baby", "verse text is not valid UTF-8"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var errs validate.Errors
+			value := tc.value
+			errs.Text("verse.text", "verse text", &value, tc.required)
+
+			if value != tc.want {
+				t.Errorf("got value %q, want %q", value, tc.want)
+			}
+			checkErrors(t, errs, "verse.text", tc.message)
+		})
+	}
+}
+
+func TestLink(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		value   string
+		message string
+	}{
+		{"https", "https://youtu.be/Xsp3_a-PMTw", ""},
+		{"http", "http://example.com/", ""},
+		{"at the limit", "https://example.com/" + strings.Repeat("\u00e9", 10), ""},
+		{"too long", "https://example.com/" + strings.Repeat("\u00e9", 11), "link is longer than 30 characters"},
+		{"relative", "/watch?v=Xsp3_a-PMTw", "invalid link"},
+		{"other scheme", "javascript:alert(1)", "invalid link"},
+		{"not a URL", "example", "invalid link"},
+		{"control character", "https://example.com/\x00", "invalid link"},
+		{"invalid UTF-8", "https://example.com/\xff", "invalid link"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var errs validate.Errors
+			errs.Link("link", "link", tc.value, 30)
+
+			checkErrors(t, errs, "link", tc.message)
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	if got := validate.Query(" Beyonce\u0301 "); got != "Beyonc\u00e9" {
+		t.Errorf("got %q, want %q", got, "Beyonc\u00e9")
+	}
+}
+
+// checkErrors checks that errs is one error of field with message, or empty
+// when message is.
+func checkErrors(t *testing.T, errs validate.Errors, field, message string) {
+	t.Helper()
+
+	var want validate.Errors
+	if message != "" {
+		want = validate.Errors{models.FieldError{Field: field, Message: message}}
+	}
+	if len(errs) != len(want) || (len(want) > 0 && errs[0] != want[0]) {
+		t.Errorf("got errors %v, want %v", errs, want)
+	}
+}
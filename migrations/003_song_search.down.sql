@@ -0,0 +1,3 @@
+DROP INDEX song_verses_text_search_idx;
+
+ALTER TABLE song_verses DROP COLUMN text_search;
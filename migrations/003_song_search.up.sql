@@ -0,0 +1,4 @@
+ALTER TABLE song_verses
+    ADD COLUMN text_search TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', text)) STORED;
+
+CREATE INDEX song_verses_text_search_idx ON song_verses USING GIN (text_search);
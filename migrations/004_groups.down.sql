@@ -0,0 +1,12 @@
+ALTER TABLE songs ADD COLUMN group_name VARCHAR(100);
+
+UPDATE songs AS s
+SET group_name = g.name
+FROM groups AS g
+WHERE g.id = s.group_id;
+
+ALTER TABLE songs ALTER COLUMN group_name SET NOT NULL;
+
+ALTER TABLE songs DROP COLUMN group_id;
+
+DROP TABLE groups;
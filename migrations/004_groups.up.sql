@@ -0,0 +1,22 @@
+CREATE TABLE groups (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+INSERT INTO groups (name)
+SELECT DISTINCT group_name FROM songs;
+
+ALTER TABLE songs ADD COLUMN group_id INTEGER REFERENCES groups(id) ON DELETE RESTRICT;
+
+UPDATE songs AS s
+SET group_id = g.id
+FROM groups AS g
+WHERE g.name = s.group_name;
+
+ALTER TABLE songs ALTER COLUMN group_id SET NOT NULL;
+
+ALTER TABLE songs DROP COLUMN group_name;
+
+CREATE INDEX songs_group_id_idx ON songs (group_id);
@@ -0,0 +1,18 @@
+CREATE TABLE playlists (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- position only orders the items and may have gaps; the API numbers items
+-- from 1 in this order.
+CREATE TABLE playlist_items (
+    id SERIAL PRIMARY KEY,
+    playlist_id INTEGER NOT NULL REFERENCES playlists(id) ON DELETE CASCADE,
+    song_id INTEGER NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    UNIQUE (playlist_id, position)
+);
+
+CREATE INDEX playlist_items_song_id_idx ON playlist_items (song_id);
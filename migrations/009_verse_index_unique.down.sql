@@ -0,0 +1 @@
+ALTER TABLE song_verses DROP CONSTRAINT IF EXISTS song_verses_song_id_verse_index_key;
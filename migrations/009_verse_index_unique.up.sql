@@ -0,0 +1,9 @@
+-- Close gaps and break ties so every song numbers its verses 1..n.
+UPDATE song_verses AS v
+SET verse_index = n.rn
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY song_id ORDER BY verse_index, id) AS rn
+      FROM song_verses) AS n
+WHERE v.id = n.id AND v.verse_index <> n.rn;
+
+ALTER TABLE song_verses
+    ADD CONSTRAINT song_verses_song_id_verse_index_key UNIQUE (song_id, verse_index);
@@ -0,0 +1,3 @@
+ALTER TABLE songs ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX songs_deleted_at_idx ON songs (deleted_at) WHERE deleted_at IS NOT NULL;
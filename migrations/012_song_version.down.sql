@@ -0,0 +1,4 @@
+DROP TRIGGER songs_touch ON songs;
+DROP FUNCTION songs_touch();
+
+ALTER TABLE songs DROP COLUMN version;
@@ -0,0 +1,20 @@
+-- version counts the changes of a song and its verses. Clients send it back
+-- as If-Match so that an edit only applies to the song they have seen.
+ALTER TABLE songs ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- Every update of a song bumps updated_at and version; changes of verses
+-- touch the song row for the same effect. Moving a song to or from the
+-- trash is not an edit.
+CREATE FUNCTION songs_touch() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    NEW.version = OLD.version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER songs_touch
+    BEFORE UPDATE ON songs
+    FOR EACH ROW
+    WHEN (OLD.deleted_at IS NOT DISTINCT FROM NEW.deleted_at)
+    EXECUTE FUNCTION songs_touch();
@@ -0,0 +1,16 @@
+ALTER TABLE songs
+    ALTER COLUMN release_date TYPE VARCHAR(50)
+        USING COALESCE(to_char(release_date, 'DD.MM.YYYY'), release_date_unparsed, ''),
+    ALTER COLUMN release_date SET NOT NULL;
+
+ALTER TABLE songs DROP COLUMN release_date_unparsed;
+
+UPDATE song_revisions
+SET before_state = jsonb_set(before_state, '{release_date}',
+        to_jsonb(COALESCE(to_char(to_date(NULLIF(before_state->>'release_date', ''), 'YYYY-MM-DD'), 'DD.MM.YYYY'), '')))
+WHERE before_state IS NOT NULL;
+
+UPDATE song_revisions
+SET after_state = jsonb_set(after_state, '{release_date}',
+        to_jsonb(COALESCE(to_char(to_date(NULLIF(after_state->>'release_date', ''), 'YYYY-MM-DD'), 'DD.MM.YYYY'), '')))
+WHERE after_state IS NOT NULL;
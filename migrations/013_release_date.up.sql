@@ -0,0 +1,46 @@
+-- release_date becomes a DATE. Values written as DD.MM.YYYY, the format the
+-- API used to require, as YYYY-MM-DD or as an ISO 8601 timestamp are
+-- converted. Any other value is kept in release_date_unparsed, with
+-- release_date left NULL, so that those songs can be found and fixed.
+CREATE FUNCTION parse_release_date(value TEXT) RETURNS DATE AS $$
+BEGIN
+    value := btrim(value);
+    IF value ~ '^\d{2}\.\d{2}\.\d{4}$' THEN
+        RETURN to_date(value, 'DD.MM.YYYY');
+    ELSIF value ~ '^\d{4}-\d{2}-\d{2}([T ].*)?$' THEN
+        RETURN to_date(substr(value, 1, 10), 'YYYY-MM-DD');
+    END IF;
+    RETURN NULL;
+EXCEPTION WHEN invalid_datetime_format OR datetime_field_overflow THEN
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+ALTER TABLE songs ADD COLUMN release_date_unparsed VARCHAR(50);
+
+-- Flagging a song is not an edit of it.
+ALTER TABLE songs DISABLE TRIGGER songs_touch;
+
+UPDATE songs
+SET release_date_unparsed = release_date
+WHERE btrim(release_date) <> '' AND parse_release_date(release_date) IS NULL;
+
+ALTER TABLE songs ENABLE TRIGGER songs_touch;
+
+ALTER TABLE songs
+    ALTER COLUMN release_date DROP NOT NULL,
+    ALTER COLUMN release_date TYPE DATE USING parse_release_date(release_date);
+
+-- Revisions hold songs as JSON, see models.SongSnapshot, where an unknown
+-- date is the empty string.
+UPDATE song_revisions
+SET before_state = jsonb_set(before_state, '{release_date}',
+        to_jsonb(COALESCE(to_char(parse_release_date(before_state->>'release_date'), 'YYYY-MM-DD'), '')))
+WHERE before_state IS NOT NULL;
+
+UPDATE song_revisions
+SET after_state = jsonb_set(after_state, '{release_date}',
+        to_jsonb(COALESCE(to_char(parse_release_date(after_state->>'release_date'), 'YYYY-MM-DD'), '')))
+WHERE after_state IS NOT NULL;
+
+DROP FUNCTION parse_release_date(TEXT);